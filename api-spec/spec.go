@@ -0,0 +1,11 @@
+// Package apispec embeds OpenAPI specification of the Todo API.
+package apispec
+
+import (
+	_ "embed"
+)
+
+// YAML is the OpenAPI specification in YAML format.
+//
+//go:embed api.yaml
+var YAML []byte
@@ -37,7 +37,7 @@ import (
 )
 
 var (
-	jsonCheck = regexp.MustCompile(`(?i:(?:application|text)/(?:vnd\.[^;]+\+)?json)`)
+	jsonCheck = regexp.MustCompile(`(?i:(?:application|text)/(?:[^;]+\+)?json)`)
 	xmlCheck  = regexp.MustCompile(`(?i:(?:application|text)/xml)`)
 )
 
@@ -49,6 +49,8 @@ type APIClient struct {
 
 	// API Services
 
+	MetaApi *MetaApiService
+
 	TodoApi *TodoApiService
 }
 
@@ -68,6 +70,7 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.common.client = c
 
 	// API Services
+	c.MetaApi = (*MetaApiService)(&c.common)
 	c.TodoApi = (*TodoApiService)(&c.common)
 
 	return c
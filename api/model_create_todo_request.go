@@ -16,7 +16,9 @@ import (
 
 // CreateTodoRequest struct for CreateTodoRequest
 type CreateTodoRequest struct {
-	Title   string `json:"title"`
+	// Maximum length is configured by the server, see getLimits
+	Title string `json:"title"`
+	// Maximum length is configured by the server, see getLimits
 	Content string `json:"content"`
 }
 
@@ -1,142 +0,0 @@
-/*
-Todo API
-
-Todo API
-
-API version: 0.1
-*/
-
-// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
-
-package api
-
-import (
-	"encoding/json"
-)
-
-// ErrorResponse struct for ErrorResponse
-type ErrorResponse struct {
-	Message string  `json:"message"`
-	Debug   *string `json:"debug,omitempty"`
-}
-
-// NewErrorResponse instantiates a new ErrorResponse object
-// This constructor will assign default values to properties that have it defined,
-// and makes sure properties required by API are set, but the set of arguments
-// will change when the set of required properties is changed
-func NewErrorResponse(message string) *ErrorResponse {
-	this := ErrorResponse{}
-	this.Message = message
-	return &this
-}
-
-// NewErrorResponseWithDefaults instantiates a new ErrorResponse object
-// This constructor will only assign default values to properties that have it defined,
-// but it doesn't guarantee that properties required by API are set
-func NewErrorResponseWithDefaults() *ErrorResponse {
-	this := ErrorResponse{}
-	return &this
-}
-
-// GetMessage returns the Message field value
-func (o *ErrorResponse) GetMessage() string {
-	if o == nil {
-		var ret string
-		return ret
-	}
-
-	return o.Message
-}
-
-// GetMessageOk returns a tuple with the Message field value
-// and a boolean to check if the value has been set.
-func (o *ErrorResponse) GetMessageOk() (*string, bool) {
-	if o == nil {
-		return nil, false
-	}
-	return &o.Message, true
-}
-
-// SetMessage sets field value
-func (o *ErrorResponse) SetMessage(v string) {
-	o.Message = v
-}
-
-// GetDebug returns the Debug field value if set, zero value otherwise.
-func (o *ErrorResponse) GetDebug() string {
-	if o == nil || o.Debug == nil {
-		var ret string
-		return ret
-	}
-	return *o.Debug
-}
-
-// GetDebugOk returns a tuple with the Debug field value if set, nil otherwise
-// and a boolean to check if the value has been set.
-func (o *ErrorResponse) GetDebugOk() (*string, bool) {
-	if o == nil || o.Debug == nil {
-		return nil, false
-	}
-	return o.Debug, true
-}
-
-// HasDebug returns a boolean if a field has been set.
-func (o *ErrorResponse) HasDebug() bool {
-	if o != nil && o.Debug != nil {
-		return true
-	}
-
-	return false
-}
-
-// SetDebug gets a reference to the given string and assigns it to the Debug field.
-func (o *ErrorResponse) SetDebug(v string) {
-	o.Debug = &v
-}
-
-func (o ErrorResponse) MarshalJSON() ([]byte, error) {
-	toSerialize := map[string]interface{}{}
-	if true {
-		toSerialize["message"] = o.Message
-	}
-	if o.Debug != nil {
-		toSerialize["debug"] = o.Debug
-	}
-	return json.Marshal(toSerialize)
-}
-
-type NullableErrorResponse struct {
-	value *ErrorResponse
-	isSet bool
-}
-
-func (v NullableErrorResponse) Get() *ErrorResponse {
-	return v.value
-}
-
-func (v *NullableErrorResponse) Set(val *ErrorResponse) {
-	v.value = val
-	v.isSet = true
-}
-
-func (v NullableErrorResponse) IsSet() bool {
-	return v.isSet
-}
-
-func (v *NullableErrorResponse) Unset() {
-	v.value = nil
-	v.isSet = false
-}
-
-func NewNullableErrorResponse(val *ErrorResponse) *NullableErrorResponse {
-	return &NullableErrorResponse{value: val, isSet: true}
-}
-
-func (v NullableErrorResponse) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.value)
-}
-
-func (v *NullableErrorResponse) UnmarshalJSON(src []byte) error {
-	v.isSet = true
-	return json.Unmarshal(src, &v.value)
-}
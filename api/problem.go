@@ -0,0 +1,17 @@
+package api
+
+import (
+	"errors"
+)
+
+// AsProblem extracts problem details from error returned by APIClient.
+func AsProblem(err error) (Problem, bool) {
+	var apiErr GenericOpenAPIError
+	if !errors.As(err, &apiErr) {
+		return Problem{}, false
+	}
+
+	problem, ok := apiErr.Model().(Problem)
+
+	return problem, ok
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader correlates requests with server logs.
+const RequestIDHeader = "X-Request-ID"
+
+// ContextRequestID takes a string request ID sent with the request, random ID is generated otherwise.
+var ContextRequestID = contextKey("requestID")
+
+// RequestID returns request ID received in problem details of error returned by APIClient.
+func RequestID(err error) (string, bool) {
+	problem, ok := AsProblem(err)
+	if !ok {
+		return "", false
+	}
+
+	return problem.GetRequestId(), problem.HasRequestId()
+}
+
+// ResponseRequestID returns request ID echoed by the server.
+func ResponseRequestID(res *http.Response) string {
+	if res == nil {
+		return ""
+	}
+
+	return res.Header.Get(RequestIDHeader)
+}
+
+func setRequestID(req *http.Request) {
+	if req.Header.Get(RequestIDHeader) != "" {
+		return
+	}
+
+	id, ok := req.Context().Value(ContextRequestID).(string)
+	if !ok || id == "" {
+		id = uuid.New().String()
+	}
+
+	req.Header.Set(RequestIDHeader, id)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// NewTransport wraps base transport, http.DefaultTransport if nil, propagating context of requests to the server.
+// Trace context is injected by propagator registered with otel.SetTextMapPropagator,
+// requests are sent with request ID of ContextRequestID or a random one.
+// Use it as transport of Configuration.HTTPClient as generated client does not modify requests.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// round tripper should not modify the original request
+	req = req.Clone(req.Context())
+	otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+	setRequestID(req)
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,100 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/shaxbee/todo-app-skaffold/api"
+)
+
+func TestTransport(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTextMapPropagator(prev)
+	})
+
+	var header http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		header = req.Header
+	}))
+	defer server.Close()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	req, err := http.NewRequestWithContext(trace.ContextWithSpanContext(context.Background(), sc), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := (&http.Client{Transport: api.NewTransport(nil)}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if expected, actual := "00-01000000000000000000000000000000-0200000000000000-01", header.Get("Traceparent"); actual != expected {
+		t.Errorf("expected traceparent %q, got %q", expected, actual)
+	}
+
+	if actual := req.Header.Get("Traceparent"); actual != "" {
+		t.Errorf("expected original request to not be modified, got traceparent %q", actual)
+	}
+}
+
+func TestTransportRequestID(t *testing.T) {
+	var requestID string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestID = req.Header.Get(api.RequestIDHeader)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		header   string
+		expected string
+	}{
+		{name: "context", ctx: context.WithValue(context.Background(), api.ContextRequestID, "from-context"), expected: "from-context"},
+		{name: "header", ctx: context.WithValue(context.Background(), api.ContextRequestID, "from-context"), header: "from-header", expected: "from-header"},
+		{name: "random", ctx: context.Background()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(tt.ctx, http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.header != "" {
+				req.Header.Set(api.RequestIDHeader, tt.header)
+			}
+
+			res, err := (&http.Client{Transport: api.NewTransport(nil)}).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			switch {
+			case tt.expected != "" && requestID != tt.expected:
+				t.Errorf("expected request id %q, got %q", tt.expected, requestID)
+			case tt.expected == "" && requestID == "":
+				t.Error("expected random request id")
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"flag"
 	"fmt"
 	"log"
@@ -9,6 +9,8 @@ import (
 
 	"github.com/pressly/goose/v3"
 
+	"github.com/shaxbee/todo-app-skaffold/internal/dbutil"
+
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
@@ -71,7 +73,8 @@ func main() {
 	default:
 	}
 
-	db, err := sql.Open("pgx", dbstring)
+	// database may still be starting when migrations run as a job
+	db, err := dbutil.Open(context.Background(), "pgx", dbstring)
 	if err != nil {
 		log.Fatalf("-dbstring=%q: %v\n", dbstring, err)
 	}
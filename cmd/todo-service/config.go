@@ -1,32 +1,204 @@
 package main
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/shaxbee/todo-app-skaffold/internal/configfile"
+	"github.com/shaxbee/todo-app-skaffold/internal/dbutil"
+	"github.com/shaxbee/todo-app-skaffold/internal/ratelimit"
+	"github.com/shaxbee/todo-app-skaffold/services/todo"
 )
 
 type Config struct {
 	Dev    bool `json:"dev" envconfig:"DEV" desc:"Development mode"`
 	Server struct {
 		Addr            string        `json:"public_addr" envconfig:"ADDR" default:":http" desc:"Server listen address"`
-		Timeout         time.Duration `json:"timeout" envconfig:"TIMEOUT" default:"5s" desc:"Operation timeout"`
-		ShutdownTimeout time.Duration `json:"shutdown_timeout" envconfig:"SHUTDOWN_TIMEOUT" default:"10s" desc:"Shutdown timeout"`
-		CorsEnabled     bool          `json:"cors_enabled" envconfig:"CORS_ENABLED" default:"false" desc:"Enable cors"`
+		Timeout         time.Duration `json:"timeout" envconfig:"SERVER_TIMEOUT" default:"5s" desc:"Operation timeout"`
+		TransferTimeout time.Duration `json:"transfer_timeout" envconfig:"TRANSFER_TIMEOUT" default:"10m" desc:"Timeout of operations streaming request or response bodies such as export, import and attachments"`
+		ShutdownTimeout time.Duration `json:"shutdown_timeout" envconfig:"SHUTDOWN_TIMEOUT" default:"10s" desc:"Timeout of each shutdown stage"`
+		DrainDelay      time.Duration `json:"drain_delay" envconfig:"DRAIN_DELAY" default:"5s" desc:"Delay between failing readiness and closing listener so that load balancers stop routing requests"`
+		H2C             bool          `json:"h2c" envconfig:"H2C" default:"false" desc:"Serve HTTP/2 without TLS to in-cluster clients"`
+		TLS             struct {
+			CertFile       string        `json:"cert_file" envconfig:"CERT_FILE" default:"" desc:"TLS certificate file, empty serves plain HTTP"`
+			KeyFile        string        `json:"key_file" envconfig:"KEY_FILE" default:"" desc:"TLS private key file"`
+			ClientCAFile   string        `json:"client_ca_file" envconfig:"CLIENT_CA_FILE" default:"" desc:"CA bundle verifying client certificates, empty disables mTLS"`
+			ReloadInterval time.Duration `json:"reload_interval" envconfig:"RELOAD_INTERVAL" default:"10s" desc:"Minimum interval of checking certificate files for changes"`
+		} `json:"tls" envconfig:"TLS"`
+		CORS struct {
+			Enabled          bool          `json:"enabled" envconfig:"CORS_ENABLED" default:"false" desc:"Enable CORS"`
+			AllowedOrigins   []string      `json:"allowed_origins" envconfig:"ALLOWED_ORIGINS" default:"" desc:"Allowed origins such as https://example.com or https://*.example.com, * allows any origin"`
+			AllowedMethods   []string      `json:"allowed_methods" envconfig:"ALLOWED_METHODS" default:"GET,HEAD,POST,PUT,DELETE" desc:"Methods allowed in preflight requests"`
+			AllowedHeaders   []string      `json:"allowed_headers" envconfig:"ALLOWED_HEADERS" default:"Content-Type,Range,X-Request-ID,X-API-Key,X-Todo-LSN" desc:"Request headers allowed in preflight requests, * allows any header"`
+			ExposedHeaders   []string      `json:"exposed_headers" envconfig:"EXPOSED_HEADERS" default:"Content-Disposition,Content-Range,X-Request-ID,X-Todo-LSN,Retry-After,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset" desc:"Response headers readable by browser clients"`
+			AllowCredentials bool          `json:"allow_credentials" envconfig:"ALLOW_CREDENTIALS" default:"false" desc:"Allow requests with credentials"`
+			MaxAge           time.Duration `json:"max_age" envconfig:"MAX_AGE" default:"10m" desc:"Duration browsers cache preflight responses"`
+		} `json:"cors" envconfig:"CORS"`
 	} `json:"server" envconfig:"SERVER"`
+	RateLimit struct {
+		Enabled           bool            `json:"enabled" envconfig:"RATE_LIMIT_ENABLED" default:"false" desc:"Enable rate limiting"`
+		Store             string          `json:"store" envconfig:"RATE_LIMIT_STORE" default:"memory" desc:"Rate limit store, one of memory or postgres to share limits between replicas"`
+		Default           ratelimit.Limit `json:"default" envconfig:"DEFAULT" default:"" desc:"Limit of routes without a route limit in RATE/UNIT[,BURST] format such as 100/s,200, empty leaves them unlimited"`
+		Routes            ratelimit.Rules `json:"routes" envconfig:"ROUTES" default:"POST /api/v1/todo=10/s,20;POST /api/v1/todo/import=1/m,5" desc:"Route limits separated by semicolon in METHOD PATH=LIMIT format"`
+		TrustForwardedFor bool            `json:"trust_forwarded_for" envconfig:"TRUST_FORWARDED_FOR" default:"false" desc:"Identify clients by X-Forwarded-For header, enable only behind a proxy that sets it"`
+		CleanupInterval   time.Duration   `json:"cleanup_interval" envconfig:"CLEANUP_INTERVAL" default:"1m" desc:"Interval of removing expired buckets from postgres store"`
+	} `json:"rate_limit" envconfig:"RATE_LIMIT"`
+	Tracing struct {
+		Exporter    string  `json:"exporter" envconfig:"EXPORTER" default:"" desc:"Trace exporter, one of otlp, stdout or empty to disable tracing"`
+		Endpoint    string  `json:"endpoint" envconfig:"TRACING_ENDPOINT" default:"http://localhost:4318" desc:"OTLP HTTP collector URL"`
+		SampleRatio float64 `json:"sample_ratio" envconfig:"SAMPLE_RATIO" default:"1" desc:"Ratio of sampled traces started by the service"`
+	} `json:"tracing" envconfig:"TRACING"`
+	Admin struct {
+		Addr string `json:"addr" envconfig:"ADMIN_ADDR" default:":9090" desc:"Admin server listen address serving metrics, empty disables admin server"`
+	} `json:"admin" envconfig:"ADMIN"`
+	Limits struct {
+		TitleLength   int `json:"title_length" envconfig:"TITLE_LENGTH" default:"20" desc:"Maximum todo title length in characters, up to 1000"`
+		ContentLength int `json:"content_length" envconfig:"CONTENT_LENGTH" default:"65536" desc:"Maximum todo content length in characters, up to 65536"`
+	} `json:"limits" envconfig:"LIMITS"`
+	Attachments struct {
+		MaxSize      int64    `json:"max_size" envconfig:"MAX_SIZE" default:"10485760" desc:"Maximum attachment size in bytes"`
+		ContentTypes []string `json:"content_types" envconfig:"CONTENT_TYPES" default:"" desc:"Accepted content types such as application/pdf or image/*, empty accepts any type"`
+		Store        string   `json:"store" envconfig:"ATTACHMENTS_STORE" default:"local" desc:"Attachment store, one of local or s3"`
+		Dir          string   `json:"dir" envconfig:"ATTACHMENTS_DIR" default:"data/attachments" desc:"Directory of local attachment store"`
+		S3           struct {
+			Endpoint  string `json:"endpoint" envconfig:"S3_ENDPOINT" default:"" desc:"S3 compatible storage URL such as https://s3.us-east-1.amazonaws.com or http://minio:9000"`
+			Region    string `json:"region" envconfig:"REGION" default:"us-east-1" desc:"Region used to sign requests"`
+			Bucket    string `json:"bucket" envconfig:"BUCKET" default:"" desc:"Bucket of attachments, it should exist"`
+			AccessKey string `json:"access_key" envconfig:"ACCESS_KEY" default:"" secret:"true" desc:"Access key, empty sends anonymous requests"`
+			SecretKey string `json:"secret_key" envconfig:"SECRET_KEY" default:"" secret:"true" desc:"Secret key"`
+		} `json:"s3" envconfig:"S3"`
+	} `json:"attachments" envconfig:"ATTACHMENTS"`
 	DB struct {
-		DSN          string `json:"dsn" envconfig:"DSN" default:"" desc:"Database data source name"`
-		MaxIdleConns int    `json:"max_idle_conns" envconfig:"MAX_IDLE_CONNS" default:"5" desc:"Database max idle connections"`
-		MaxOpenConns int    `json:"max_open_conns" envconfig:"MAX_OPEN_CONNS" default:"20" desc:"Database max open connections"`
+		Driver               string        `json:"driver" envconfig:"DRIVER" default:"" desc:"Database driver, one of pgx, sqlite or memory which keeps todos in memory of the process, empty selects driver by DSN scheme"`
+		DSN                  string        `json:"dsn" envconfig:"DSN" default:"" secret:"true" desc:"Database data source name, postgres:// or sqlite:// URL"`
+		MaxIdleConns         int           `json:"max_idle_conns" envconfig:"MAX_IDLE_CONNS" default:"5" desc:"Database connections kept open when idle, minimum pool size"`
+		MaxOpenConns         int           `json:"max_open_conns" envconfig:"MAX_OPEN_CONNS" default:"20" desc:"Database max open connections, maximum pool size"`
+		ConnMaxLifetime      time.Duration `json:"conn_max_lifetime" envconfig:"CONN_MAX_LIFETIME" default:"30m" desc:"Database connections older than lifetime are closed, zero disables"`
+		ConnMaxIdleTime      time.Duration `json:"conn_max_idle_time" envconfig:"CONN_MAX_IDLE_TIME" default:"5m" desc:"Database connections idle for longer are closed, zero disables"`
+		ConnectTimeout       time.Duration `json:"connect_timeout" envconfig:"CONNECT_TIMEOUT" default:"1m" desc:"Timeout of waiting for database to become reachable on startup"`
+		SlowQueryThreshold   time.Duration `json:"slow_query_threshold" envconfig:"SLOW_QUERY_THRESHOLD" default:"200ms" desc:"Queries slower than threshold are logged as warnings, zero disables slow query logging"`
+		ReplicaDSN           string        `json:"replica_dsn" envconfig:"REPLICA_DSN" default:"" secret:"true" desc:"Read replica data source name, empty sends reads to primary"`
+		ReplicaCheckInterval time.Duration `json:"replica_check_interval" envconfig:"REPLICA_CHECK_INTERVAL" default:"1s" desc:"Interval of checking replica health and replication progress"`
+		ReadYourWritesWindow time.Duration `json:"read_your_writes_window" envconfig:"READ_YOUR_WRITES_WINDOW" default:"10s" desc:"Reads after write by the same client are sent to primary until replica catches up within window"`
 	} `json:"db" envconfig:"DB"`
 }
 
-func parseConfig() (*Config, error) {
+// parseConfig reads optional config file and environment variables, environment variables take precedence.
+func parseConfig(file string) (*Config, error) {
 	var c Config
 
-	if err := envconfig.Process("TODO", &c); err != nil {
+	if err := configfile.Load("TODO", file, &c); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
 		return nil, err
 	}
 
 	return &c, nil
 }
+
+// DBDriver returns configured database driver or selects it by DSN scheme when driver is not set.
+func (c *Config) DBDriver() string {
+	switch {
+	case c.DB.Driver != "":
+		return c.DB.Driver
+	case dbutil.IsSQLite(c.DB.DSN):
+		return "sqlite"
+	default:
+		return "pgx"
+	}
+}
+
+// TodoLimits returns configured limits of todo fields.
+func (c *Config) TodoLimits() todo.Limits {
+	return todo.Limits{
+		TitleLength:   c.Limits.TitleLength,
+		ContentLength: c.Limits.ContentLength,
+	}
+}
+
+// Validate checks values that can't be enforced by types.
+func (c *Config) Validate() error {
+	var problems []string
+
+	switch c.Tracing.Exporter {
+	case "", "otlp", "stdout":
+	default:
+		problems = append(problems, fmt.Sprintf("tracing.exporter: unsupported exporter %q", c.Tracing.Exporter))
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, "tracing.sample_ratio: should be between 0 and 1")
+	}
+
+	switch driver := c.DBDriver(); driver {
+	case "pgx":
+	case "sqlite", "memory":
+		if c.RateLimit.Enabled && c.RateLimit.Store == "postgres" {
+			problems = append(problems, "rate_limit.store: postgres store requires pgx driver")
+		}
+
+		if c.DB.ReplicaDSN != "" {
+			problems = append(problems, "db.replica_dsn: read replica requires pgx driver")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("db.driver: unsupported driver %q", driver))
+	}
+
+	if err := c.TodoLimits().Validate(); err != nil {
+		problems = append(problems, "limits: "+err.Error())
+	}
+
+	if c.Attachments.MaxSize <= 0 {
+		problems = append(problems, "attachments.max_size: should be positive")
+	}
+
+	switch c.Attachments.Store {
+	case "local":
+		if c.Attachments.Dir == "" {
+			problems = append(problems, "attachments.dir: should be set for local store")
+		}
+	case "s3":
+		if c.Attachments.S3.Endpoint == "" || c.Attachments.S3.Bucket == "" {
+			problems = append(problems, "attachments.s3: endpoint and bucket should be set for s3 store")
+		}
+
+		if (c.Attachments.S3.AccessKey == "") != (c.Attachments.S3.SecretKey == "") {
+			problems = append(problems, "attachments.s3: access_key and secret_key should be set together")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("attachments.store: unsupported store %q", c.Attachments.Store))
+	}
+
+	if c.DB.ReplicaDSN != "" && c.DB.ReplicaCheckInterval <= 0 {
+		problems = append(problems, "db.replica_check_interval: should be positive")
+	}
+
+	switch c.RateLimit.Store {
+	case "memory", "postgres":
+	default:
+		problems = append(problems, fmt.Sprintf("rate_limit.store: unsupported store %q", c.RateLimit.Store))
+	}
+
+	if tls := c.Server.TLS; (tls.CertFile == "") != (tls.KeyFile == "") {
+		problems = append(problems, "server.tls: cert_file and key_file should be set together")
+	} else if tls.ClientCAFile != "" && tls.CertFile == "" {
+		problems = append(problems, "server.tls.client_ca_file: requires cert_file and key_file")
+	}
+
+	if c.Server.ShutdownTimeout <= 0 {
+		problems = append(problems, "server.shutdown_timeout: should be positive")
+	}
+
+	if c.Server.DrainDelay < 0 {
+		problems = append(problems, "server.drain_delay: should not be negative")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestConfigEnv(t *testing.T) {
+	// ops set unprefixed ADDR which envconfig reads for fields tagged ADDR without TODO_ prefixed variable
+	setenv(t, "ADDR", ":8080")
+
+	config, err := parseConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Server.Addr != ":8080" {
+		t.Errorf("expected server address from ADDR, got %q", config.Server.Addr)
+	}
+
+	if config.Admin.Addr != ":9090" {
+		t.Errorf("expected default admin address, got %q", config.Admin.Addr)
+	}
+
+	setenv(t, "TODO_ADMIN_ADMIN_ADDR", ":9091")
+
+	config, err = parseConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Admin.Addr != ":9091" {
+		t.Errorf("expected admin address from TODO_ADMIN_ADMIN_ADDR, got %q", config.Admin.Addr)
+	}
+}
+
+// TestConfigEnvTags checks that tags are unique as envconfig falls back to unprefixed tag when prefixed variable is not set.
+func TestConfigEnvTags(t *testing.T) {
+	fields := make(map[string]string)
+
+	var walk func(typ reflect.Type, path string)
+	walk = func(typ reflect.Type, path string) {
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name := path + field.Name
+
+			// sections prefix their fields, named types such as limits are read from a single variable
+			if field.Type.Kind() == reflect.Struct && field.Type.Name() == "" {
+				walk(field.Type, name+".")
+				continue
+			}
+
+			tag := field.Tag.Get("envconfig")
+			if other, ok := fields[tag]; ok {
+				t.Errorf("expected unique envconfig tag %q of %s, also used by %s", tag, name, other)
+			}
+
+			fields[tag] = name
+		}
+	}
+
+	walk(reflect.TypeOf(Config{}), "")
+}
+
+// setenv sets environment variable for the duration of test, testing.T.Setenv requires Go 1.17.
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+
+	prev, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, prev)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
@@ -1,42 +1,123 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"database/sql"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/goes-funky/httprouter"
-	"github.com/goes-funky/httprouter/middleware/cors"
 	"github.com/goes-funky/httprouter/zapdriver"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
 	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
+	apispec "github.com/shaxbee/todo-app-skaffold/api-spec"
+	"github.com/shaxbee/todo-app-skaffold/internal/apidoc"
+	"github.com/shaxbee/todo-app-skaffold/internal/blob"
+	"github.com/shaxbee/todo-app-skaffold/internal/cors"
+	"github.com/shaxbee/todo-app-skaffold/internal/dbrouter"
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtx"
+	"github.com/shaxbee/todo-app-skaffold/internal/dbutil"
+	"github.com/shaxbee/todo-app-skaffold/internal/health"
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+	"github.com/shaxbee/todo-app-skaffold/internal/metrics"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/internal/ratelimit"
+	"github.com/shaxbee/todo-app-skaffold/internal/requestid"
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+	"github.com/shaxbee/todo-app-skaffold/internal/tlsutil"
+	"github.com/shaxbee/todo-app-skaffold/internal/tracing"
+	"github.com/shaxbee/todo-app-skaffold/internal/validation"
 	"github.com/shaxbee/todo-app-skaffold/services/todo"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/migrations"
 )
 
 type container struct {
 	config *Config
 
 	state struct {
-		logger     *zap.Logger
-		db         *sql.DB
-		todoServer *todo.Server
-		httpRouter *httprouter.Router
-		httpServer *http.Server
-		listener   net.Listener
+		logger           *zap.Logger
+		pool             *pgxpool.Pool
+		poolErr          error
+		db               *sql.DB
+		dbErr            error
+		replica          *pgxpool.Pool
+		replicaErr       error
+		dbRouter         *dbrouter.Router
+		dbRouterErr      error
+		tracer           *sdktrace.TracerProvider
+		tracerErr        error
+		registry         *prometheus.Registry
+		registryErr      error
+		metrics          *metrics.Metrics
+		metricsErr       error
+		todoStore        todo.Store
+		todoStoreErr     error
+		blobStore        todo.BlobStore
+		blobStoreErr     error
+		todoServer       *todo.Server
+		todoServerErr    error
+		validator        *validation.Validator
+		validatorErr     error
+		rateLimiter      *ratelimit.Limiter
+		rateLimiterErr   error
+		rateLimits       ratelimit.Store
+		rateLimitsErr    error
+		apidoc           *apidoc.Server
+		apidocErr        error
+		health           *health.Checker
+		healthErr        error
+		httpRouter       *httprouter.Router
+		httpRouterErr    error
+		httpHandler      http.Handler
+		httpHandlerErr   error
+		httpServer       *http.Server
+		httpServerErr    error
+		tlsConfig        *tls.Config
+		tlsConfigErr     error
+		listener         net.Listener
+		listenerErr      error
+		adminRouter      *http.ServeMux
+		adminRouterErr   error
+		adminServer      *http.Server
+		adminServerErr   error
+		adminListener    net.Listener
+		adminListenerErr error
+		serving          bool
 	}
 
 	once struct {
-		logger, db, todoServer, httpRouter, httpServer, listener sync.Once
+		logger, pool, db, replica, dbRouter, tracer, registry, metrics, todoStore, blobStore, todoServer, validator, rateLimiter, rateLimits, apidoc, health, httpRouter, httpHandler, httpServer, tlsConfig, listener, adminRouter, adminServer, adminListener sync.Once
+	}
+
+	workers struct {
+		ctx    context.Context
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
 	}
 }
 
 func newContainer(config *Config) *container {
-	return &container{
+	c := &container{
 		config: config,
 	}
+
+	c.workers.ctx, c.workers.cancel = context.WithCancel(context.Background())
+
+	return c
 }
 
 func (c *container) logger() *zap.Logger {
@@ -61,37 +142,475 @@ func (c *container) logger() *zap.Logger {
 	return c.state.logger
 }
 
-func (c *container) db() *sql.DB {
+// pool connects to database, it waits for database to become reachable up to connect timeout.
+func (c *container) pool() (*pgxpool.Pool, error) {
+	c.once.pool.Do(func() {
+		if c.state.pool != nil {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.DB.ConnectTimeout)
+		defer cancel()
+
+		c.state.pool, c.state.poolErr = dbutil.OpenPool(ctx, c.config.DB.DSN, c.dbOpts()...)
+	})
+
+	return c.state.pool, c.state.poolErr
+}
+
+// db adapts pool to database/sql for libraries that don't support pgx such as goose.
+// With sqlite driver it opens the database file and applies migrations as there is no separate migration job.
+func (c *container) db() (*sql.DB, error) {
 	c.once.db.Do(func() {
 		if c.state.db != nil {
 			return
 		}
 
-		db, err := sql.Open("pgx", c.config.DB.DSN)
+		if c.config.DBDriver() == "sqlite" {
+			c.state.db, c.state.dbErr = c.openSQLite()
+			return
+		}
+
+		pool, err := c.pool()
+		if err != nil {
+			c.state.dbErr = err
+			return
+		}
+
+		c.state.db = dbutil.SQL(pool, c.dbOpts()...)
+	})
+
+	return c.state.db, c.state.dbErr
+}
+
+func (c *container) openSQLite() (*sql.DB, error) {
+	dsn, err := dbutil.SQLiteDSN(c.config.DB.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.DB.ConnectTimeout)
+	defer cancel()
+
+	db, err := dbutil.Open(ctx, dbutil.SQLiteDriver, dsn, c.dbOpts()...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dbutil.Migrate(db, "sqlite3", migrations.SQLite); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// replica connects to read replica lazily so that unavailable replica doesn't prevent startup.
+func (c *container) replica() (*pgxpool.Pool, error) {
+	c.once.replica.Do(func() {
+		if c.state.replica != nil {
+			return
+		}
+
+		c.state.replica, c.state.replicaErr = dbutil.OpenPool(context.Background(), c.config.DB.ReplicaDSN, append(c.dbOpts(), dbutil.Lazy(true))...)
+	})
+
+	return c.state.replica, c.state.replicaErr
+}
+
+// dbRouter sends reads to replica, it's nil if replica is not configured.
+func (c *container) dbRouter() (*dbrouter.Router, error) {
+	c.once.dbRouter.Do(func() {
+		if c.config.DBDriver() != "pgx" || c.config.DB.ReplicaDSN == "" {
+			return
+		}
+
+		pool, err := c.pool()
+		if err != nil {
+			c.state.dbRouterErr = err
+			return
+		}
+
+		replica, err := c.replica()
+		if err != nil {
+			c.state.dbRouterErr = err
+			return
+		}
+
+		c.state.dbRouter = dbrouter.New(pool, replica,
+			dbrouter.Logger(c.logger()),
+			dbrouter.Reads(todo.ReadQueries...),
+			dbrouter.CheckInterval(c.config.DB.ReplicaCheckInterval),
+		)
+	})
+
+	return c.state.dbRouter, c.state.dbRouterErr
+}
+
+func (c *container) dbOpts() []dbutil.Opt {
+	return []dbutil.Opt{
+		dbutil.MaxIdleConns(c.config.DB.MaxIdleConns),
+		dbutil.MaxOpenConns(c.config.DB.MaxOpenConns),
+		dbutil.ConnMaxLifetime(c.config.DB.ConnMaxLifetime),
+		dbutil.ConnMaxIdleTime(c.config.DB.ConnMaxIdleTime),
+	}
+}
+
+func (c *container) tracer() (*sdktrace.TracerProvider, error) {
+	c.once.tracer.Do(func() {
+		provider, err := tracing.NewProvider(
+			tracing.ServiceName("todo-service"),
+			tracing.Exporter(c.config.Tracing.Exporter),
+			tracing.Endpoint(c.config.Tracing.Endpoint),
+			tracing.SampleRatio(c.config.Tracing.SampleRatio),
+		)
 		if err != nil {
-			c.logger().Fatal("db", zap.String("dsn", c.config.DB.DSN), zap.Error(err))
+			c.state.tracerErr = err
+			return
 		}
 
-		db.SetMaxIdleConns(c.config.DB.MaxIdleConns)
-		db.SetMaxOpenConns(c.config.DB.MaxOpenConns)
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
-		c.state.db = db
+		c.state.tracer = provider
 	})
 
-	return c.state.db
+	return c.state.tracer, c.state.tracerErr
 }
 
-func (c *container) todoServer() *todo.Server {
+func (c *container) registry() (*prometheus.Registry, error) {
+	c.once.registry.Do(func() {
+		registry := prometheus.NewRegistry()
+
+		collectors := []prometheus.Collector{
+			prometheus.NewGoCollector(),
+			prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		}
+
+		switch c.config.DBDriver() {
+		case "pgx":
+			pool, err := c.pool()
+			if err != nil {
+				c.state.registryErr = err
+				return
+			}
+
+			db, err := c.db()
+			if err != nil {
+				c.state.registryErr = err
+				return
+			}
+
+			collectors = append(collectors,
+				metrics.NewPoolStatsCollector(pool, "todo"),
+				metrics.NewDBStatsCollector(db, "todo"),
+			)
+		case "sqlite":
+			db, err := c.db()
+			if err != nil {
+				c.state.registryErr = err
+				return
+			}
+
+			collectors = append(collectors, metrics.NewDBStatsCollector(db, "todo"))
+		}
+
+		for _, collector := range collectors {
+			if err := registry.Register(collector); err != nil {
+				c.state.registryErr = fmt.Errorf("failed to register collector: %w", err)
+				return
+			}
+		}
+
+		c.state.registry = registry
+	})
+
+	return c.state.registry, c.state.registryErr
+}
+
+func (c *container) metrics() (*metrics.Metrics, error) {
+	c.once.metrics.Do(func() {
+		registry, err := c.registry()
+		if err != nil {
+			c.state.metricsErr = err
+			return
+		}
+
+		c.state.metrics, c.state.metricsErr = metrics.New(registry)
+	})
+
+	return c.state.metrics, c.state.metricsErr
+}
+
+func (c *container) todoStore() (todo.Store, error) {
+	c.once.todoStore.Do(func() {
+		if c.state.todoStore != nil {
+			return
+		}
+
+		switch driver := c.config.DBDriver(); driver {
+		case "memory":
+			c.state.todoStore = todo.NewMemoryStore()
+		case "sqlite":
+			db, err := c.db()
+			if err != nil {
+				c.state.todoStoreErr = err
+				return
+			}
+
+			m, err := c.metrics()
+			if err != nil {
+				c.state.todoStoreErr = err
+				return
+			}
+
+			tracer, err := c.tracer()
+			if err != nil {
+				c.state.todoStoreErr = err
+				return
+			}
+
+			c.state.todoStore = todo.NewSQLiteStore(db, todo.Interceptors(
+				tracing.Interceptor(tracer, semconv.DBSystemSqlite),
+				m.Interceptor(),
+				dbtx.Log(c.logger(), c.config.DB.SlowQueryThreshold),
+			))
+		case "pgx":
+			pool, err := c.pool()
+			if err != nil {
+				c.state.todoStoreErr = err
+				return
+			}
+
+			router, err := c.dbRouter()
+			if err != nil {
+				c.state.todoStoreErr = err
+				return
+			}
+
+			m, err := c.metrics()
+			if err != nil {
+				c.state.todoStoreErr = err
+				return
+			}
+
+			tracer, err := c.tracer()
+			if err != nil {
+				c.state.todoStoreErr = err
+				return
+			}
+
+			var primary dbtx.DBTX = pool
+			if router != nil {
+				primary = router
+			}
+
+			db := dbtx.Wrap(primary,
+				tracing.Interceptor(tracer, semconv.DBSystemPostgreSQL),
+				m.Interceptor(),
+				dbtx.Log(c.logger(), c.config.DB.SlowQueryThreshold),
+			)
+			c.state.todoStore = todo.NewPostgresStore(db)
+		default:
+			c.state.todoStoreErr = fmt.Errorf("unsupported database driver %q", driver)
+		}
+	})
+
+	return c.state.todoStore, c.state.todoStoreErr
+}
+
+func (c *container) blobStore() (todo.BlobStore, error) {
+	c.once.blobStore.Do(func() {
+		if c.state.blobStore != nil {
+			return
+		}
+
+		cfg := c.config.Attachments
+
+		switch cfg.Store {
+		case "local":
+			c.state.blobStore, c.state.blobStoreErr = blob.NewLocalStore(cfg.Dir)
+		case "s3":
+			c.state.blobStore, c.state.blobStoreErr = blob.NewS3Store(cfg.S3.Endpoint, cfg.S3.Bucket,
+				blob.Region(cfg.S3.Region),
+				blob.Credentials(cfg.S3.AccessKey, cfg.S3.SecretKey),
+			)
+		default:
+			c.state.blobStoreErr = fmt.Errorf("unsupported attachment store %q", cfg.Store)
+		}
+	})
+
+	return c.state.blobStore, c.state.blobStoreErr
+}
+
+func (c *container) todoServer() (*todo.Server, error) {
 	c.once.todoServer.Do(func() {
-		c.state.todoServer = todo.NewServer(c.db())
+		store, err := c.todoStore()
+		if err != nil {
+			c.state.todoServerErr = err
+			return
+		}
+
+		blobs, err := c.blobStore()
+		if err != nil {
+			c.state.todoServerErr = err
+			return
+		}
+
+		c.state.todoServer = todo.NewServer(todo.NewService(store,
+			todo.FieldLimits(c.config.TodoLimits()),
+			todo.Blobs(blobs),
+			todo.AttachmentLimits(c.config.Attachments.MaxSize, c.config.Attachments.ContentTypes),
+			todo.Logger(c.logger()),
+		))
+	})
+
+	return c.state.todoServer, c.state.todoServerErr
+}
+
+func (c *container) validator() (*validation.Validator, error) {
+	c.once.validator.Do(func() {
+		c.state.validator, c.state.validatorErr = validation.New(context.Background(), apispec.YAML,
+			validation.Logger(c.logger()),
+			validation.ValidateResponses(c.config.Dev),
+		)
+	})
+
+	return c.state.validator, c.state.validatorErr
+}
+
+func (c *container) rateLimits() (ratelimit.Store, error) {
+	c.once.rateLimits.Do(func() {
+		switch c.config.RateLimit.Store {
+		case "memory":
+			c.state.rateLimits = ratelimit.NewMemoryStore()
+		case "postgres":
+			pool, err := c.pool()
+			if err != nil {
+				c.state.rateLimitsErr = err
+				return
+			}
+
+			c.state.rateLimits = ratelimit.NewPostgresStore(pool)
+		default:
+			c.state.rateLimitsErr = fmt.Errorf("unsupported rate limit store %q", c.config.RateLimit.Store)
+		}
+	})
+
+	return c.state.rateLimits, c.state.rateLimitsErr
+}
+
+func (c *container) rateLimiter() (*ratelimit.Limiter, error) {
+	c.once.rateLimiter.Do(func() {
+		store, err := c.rateLimits()
+		if err != nil {
+			c.state.rateLimiterErr = err
+			return
+		}
+
+		c.state.rateLimiter = ratelimit.New(store,
+			ratelimit.Logger(c.logger()),
+			ratelimit.Default(c.config.RateLimit.Default),
+			ratelimit.Routes(c.config.RateLimit.Routes),
+			ratelimit.TrustForwardedFor(c.config.RateLimit.TrustForwardedFor),
+		)
+	})
+
+	return c.state.rateLimiter, c.state.rateLimiterErr
+}
+
+func (c *container) apidoc() (*apidoc.Server, error) {
+	c.once.apidoc.Do(func() {
+		c.state.apidoc, c.state.apidocErr = apidoc.New(apispec.YAML, apidoc.Explorer(c.config.Dev))
+	})
+
+	return c.state.apidoc, c.state.apidocErr
+}
+
+func (c *container) health() (*health.Checker, error) {
+	c.once.health.Do(func() {
+		opts := []health.Opt{
+			health.Logger(c.logger()),
+		}
+
+		// memory store is always available
+		switch c.config.DBDriver() {
+		case "sqlite":
+			db, err := c.db()
+			if err != nil {
+				c.state.healthErr = err
+				return
+			}
+
+			opts = append(opts, health.Check("db", db.PingContext))
+		case "pgx":
+			pool, err := c.pool()
+			if err != nil {
+				c.state.healthErr = err
+				return
+			}
+
+			db, err := c.db()
+			if err != nil {
+				c.state.healthErr = err
+				return
+			}
+
+			latest, err := dbutil.LatestMigration(migrations.FS)
+			if err != nil {
+				c.state.healthErr = err
+				return
+			}
+
+			opts = append(opts,
+				health.Check("db", pool.Ping),
+				health.Check("migrations", func(ctx context.Context) error {
+					version, err := dbutil.MigrationVersion(ctx, db)
+					if err != nil {
+						return err
+					}
+
+					// newer schema is accepted so that old replicas keep serving during rolling update
+					if version < latest {
+						return fmt.Errorf("database migration version %d is behind %d", version, latest)
+					}
+
+					return nil
+				}),
+			)
+		}
+
+		c.state.health = health.New(opts...)
 	})
 
-	return c.state.todoServer
+	return c.state.health, c.state.healthErr
 }
 
-func (c *container) httpRouter() *httprouter.Router {
+func (c *container) httpRouter() (*httprouter.Router, error) {
 	c.once.httpRouter.Do(func() {
-		todoServer := c.todoServer()
+		todoServer, err := c.todoServer()
+		if err != nil {
+			c.state.httpRouterErr = err
+			return
+		}
+
+		m, err := c.metrics()
+		if err != nil {
+			c.state.httpRouterErr = err
+			return
+		}
+
+		dbRouter, err := c.dbRouter()
+		if err != nil {
+			c.state.httpRouterErr = err
+			return
+		}
+
+		checker, err := c.health()
+		if err != nil {
+			c.state.httpRouterErr = err
+			return
+		}
 
 		opts := []httprouter.Opt{
 			httprouter.WithVerbose(c.config.Dev),
@@ -99,41 +618,225 @@ func (c *container) httpRouter() *httprouter.Router {
 
 		opts = append(opts, zapdriver.RouterOpts(c.logger())...)
 
-		if c.config.Server.CorsEnabled {
-			opts = append(opts, cors.RouterOpts()...)
+		router := httprouter.New(opts...)
+
+		middleware := []routes.Middleware{
+			tracing.Middleware(),
+			logging.AccessLog(c.logger()),
+			m.Middleware(),
+			problem.Middleware(c.logger(), c.config.Dev),
+			routes.Timeout(c.config.Server.Timeout, transferTimeouts(c.config.Server.TransferTimeout)),
 		}
 
-		router := httprouter.New(opts...)
-		todoServer.RegisterRoutes(router)
+		// rejected requests are not validated
+		if c.config.RateLimit.Enabled {
+			limiter, err := c.rateLimiter()
+			if err != nil {
+				c.state.httpRouterErr = err
+				return
+			}
+
+			middleware = append(middleware, limiter.Middleware())
+		}
+
+		if dbRouter != nil {
+			middleware = append(middleware, dbRouter.Middleware(c.config.DB.ReadYourWritesWindow))
+		}
+
+		validator, err := c.validator()
+		if err != nil {
+			c.state.httpRouterErr = err
+			return
+		}
+
+		middleware = append(middleware, validator.Middleware())
+
+		mux := routes.New(router, middleware...)
+		todoServer.RegisterRoutes(mux)
+
+		// routes not documented in the spec, excluded from access log to avoid logging probes
+		internal := routes.New(router,
+			tracing.Middleware(),
+			m.Middleware(),
+			problem.Middleware(c.logger(), c.config.Dev),
+		)
+		doc, err := c.apidoc()
+		if err != nil {
+			c.state.httpRouterErr = err
+			return
+		}
+
+		doc.RegisterRoutes(internal)
+		checker.RegisterRoutes(internal)
 
 		c.state.httpRouter = router
 	})
 
-	return c.state.httpRouter
+	return c.state.httpRouter, c.state.httpRouterErr
+}
+
+// transferTimeouts of routes that stream request or response bodies, their duration depends on size of the body.
+func transferTimeouts(timeout time.Duration) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(todo.TransferRoutes))
+	for _, route := range todo.TransferRoutes {
+		timeouts[route] = timeout
+	}
+
+	return timeouts
 }
 
-func (c *container) httpServer() *http.Server {
+// httpHandler wraps router with handlers that apply to every request including unrouted ones.
+func (c *container) httpHandler() (http.Handler, error) {
+	c.once.httpHandler.Do(func() {
+		if c.state.httpHandler != nil {
+			return
+		}
+
+		router, err := c.httpRouter()
+		if err != nil {
+			c.state.httpHandlerErr = err
+			return
+		}
+
+		tracer, err := c.tracer()
+		if err != nil {
+			c.state.httpHandlerErr = err
+			return
+		}
+
+		var handler http.Handler = router
+
+		if cfg := c.config.Server.CORS; cfg.Enabled {
+			handler = cors.New(
+				cors.Logger(c.logger()),
+				cors.AllowedOrigins(cfg.AllowedOrigins...),
+				cors.AllowedMethods(cfg.AllowedMethods...),
+				cors.AllowedHeaders(cfg.AllowedHeaders...),
+				cors.ExposedHeaders(cfg.ExposedHeaders...),
+				cors.AllowCredentials(cfg.AllowCredentials),
+				cors.MaxAge(cfg.MaxAge),
+			).Handler(handler)
+		}
+
+		// request ID and span are set before router so that router level request logs carry them
+		handler = requestid.Handler(handler)
+		handler = tracing.Handler(tracer, otel.GetTextMapPropagator(), handler)
+
+		// requests upgraded to HTTP/2 without TLS are passed to handler, TLS connections negotiate HTTP/2 on their own
+		if c.config.Server.H2C {
+			handler = h2c.NewHandler(handler, &http2.Server{})
+		}
+
+		c.state.httpHandler = handler
+	})
+
+	return c.state.httpHandler, c.state.httpHandlerErr
+}
+
+func (c *container) httpServer() (*http.Server, error) {
 	c.once.httpServer.Do(func() {
+		handler, err := c.httpHandler()
+		if err != nil {
+			c.state.httpServerErr = err
+			return
+		}
+
 		c.state.httpServer = &http.Server{
-			Addr:         c.config.Server.Addr,
-			ReadTimeout:  c.config.Server.Timeout,
-			WriteTimeout: c.config.Server.Timeout,
-			Handler:      c.httpRouter(),
+			Addr: c.config.Server.Addr,
+			// operations are bounded by request context deadline, connection timeouts allow transfers
+			ReadHeaderTimeout: c.config.Server.Timeout,
+			ReadTimeout:       c.config.Server.TransferTimeout,
+			WriteTimeout:      c.config.Server.TransferTimeout,
+			Handler:           handler,
+		}
+	})
+
+	return c.state.httpServer, c.state.httpServerErr
+}
+
+// tlsConfig returns nil if TLS is not configured.
+func (c *container) tlsConfig() (*tls.Config, error) {
+	c.once.tlsConfig.Do(func() {
+		cfg := c.config.Server.TLS
+		if cfg.CertFile == "" && cfg.KeyFile == "" {
+			return
 		}
+
+		c.state.tlsConfig, c.state.tlsConfigErr = tlsutil.NewServerConfig(cfg.CertFile, cfg.KeyFile,
+			tlsutil.ClientCAs(cfg.ClientCAFile),
+			tlsutil.ReloadInterval(cfg.ReloadInterval),
+			tlsutil.Logger(c.logger()),
+		)
 	})
 
-	return c.state.httpServer
+	return c.state.tlsConfig, c.state.tlsConfigErr
 }
 
-func (c *container) listener() net.Listener {
+func (c *container) listener() (net.Listener, error) {
 	c.once.listener.Do(func() {
+		// certificates are checked before binding the address
+		config, err := c.tlsConfig()
+		if err != nil {
+			c.state.listenerErr = err
+			return
+		}
+
 		listener, err := net.Listen("tcp", c.config.Server.Addr)
 		if err != nil {
-			c.logger().Fatal("listener", zap.String("addr", c.config.Server.Addr), zap.Error(err))
+			c.state.listenerErr = err
+			return
+		}
+
+		if config != nil {
+			listener = tls.NewListener(listener, config)
 		}
 
 		c.state.listener = listener
 	})
 
-	return c.state.listener
+	return c.state.listener, c.state.listenerErr
+}
+
+func (c *container) adminRouter() (*http.ServeMux, error) {
+	c.once.adminRouter.Do(func() {
+		registry, err := c.registry()
+		if err != nil {
+			c.state.adminRouterErr = err
+			return
+		}
+
+		router := http.NewServeMux()
+		router.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+		c.state.adminRouter = router
+	})
+
+	return c.state.adminRouter, c.state.adminRouterErr
+}
+
+func (c *container) adminServer() (*http.Server, error) {
+	c.once.adminServer.Do(func() {
+		router, err := c.adminRouter()
+		if err != nil {
+			c.state.adminServerErr = err
+			return
+		}
+
+		c.state.adminServer = &http.Server{
+			Addr:         c.config.Admin.Addr,
+			ReadTimeout:  c.config.Server.Timeout,
+			WriteTimeout: c.config.Server.Timeout,
+			Handler:      router,
+		}
+	})
+
+	return c.state.adminServer, c.state.adminServerErr
+}
+
+func (c *container) adminListener() (net.Listener, error) {
+	c.once.adminListener.Do(func() {
+		c.state.adminListener, c.state.adminListenerErr = net.Listen("tcp", c.config.Admin.Addr)
+	})
+
+	return c.state.adminListener, c.state.adminListenerErr
 }
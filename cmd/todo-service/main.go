@@ -2,65 +2,213 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/shaxbee/todo-app-skaffold/internal/configfile"
+	"github.com/shaxbee/todo-app-skaffold/internal/ratelimit"
+
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
+const usage = `Usage: todo-service [flags] [command]
+
+Commands:
+  config print     print effective config with secrets redacted
+  config validate  check config without starting the server
+
+Without command the server is started.
+
+Flags:
+`
+
 func main() {
-	config, err := parseConfig()
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	configFile := flag.String("config", os.Getenv("TODO_CONFIG_FILE"), "YAML or JSON config file, environment variables take precedence")
+	flag.Parse()
+
+	config, err := parseConfig(*configFile)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
+	switch command := strings.Join(flag.Args(), " "); command {
+	case "":
+	case "config print":
+		if err := configfile.Print(os.Stdout, config); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	case "config validate":
+		fmt.Println("config is valid")
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", command)
+		flag.Usage()
+		os.Exit(2)
+	}
+
 	container := newContainer(config)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
 	if err := run(ctx, container); err != nil {
-		container.logger().Error("run", zap.Error(err))
+		container.logger().Fatal("run", zap.Error(err))
 	}
 }
 
-func run(ctx context.Context, c *container) error {
-	server := c.httpServer()
+// run serves until ctx is done or a server fails and shuts the container down.
+// Components created before startup fails are released by the same shutdown stages.
+func run(ctx context.Context, c *container) (err error) {
+	defer func() {
+		if err == nil || c.state.serving {
+			return
+		}
 
-	listener := c.listener()
-	addr := listener.Addr().String()
-	c.logger().Info("server started", zap.String("addr", addr))
+		// servers close listeners only once serving
+		if c.state.listener != nil {
+			_ = c.state.listener.Close()
+		}
 
-	errg, ctx := errgroup.WithContext(ctx)
+		// failed stages are logged
+		_ = c.shutdown()
+	}()
 
-	errg.Go(func() error {
-		<-ctx.Done()
+	// fail fast if database is not reachable rather than starting unhealthy
+	switch c.config.DBDriver() {
+	case "pgx":
+		if _, err := c.pool(); err != nil {
+			return err
+		}
+	case "sqlite":
+		if _, err := c.db(); err != nil {
+			return err
+		}
+	}
 
-		sctx, cancel := context.WithTimeout(context.Background(), c.config.Server.ShutdownTimeout)
-		defer cancel()
+	// readiness is failed first during shutdown
+	if _, err := c.health(); err != nil {
+		return err
+	}
+
+	// components depending on database are resolved before serving so that failures stop startup
+	server, err := c.httpServer()
+	if err != nil {
+		return err
+	}
 
-		if err := server.Shutdown(sctx); err != nil {
-			return fmt.Errorf("shutdown: %w", err)
+	var adminServer *http.Server
+	if c.config.Admin.Addr != "" {
+		if adminServer, err = c.adminServer(); err != nil {
+			return err
 		}
+	}
 
-		c.logger().Info("server shutdown", zap.String("addr", addr))
-		return nil
+	router, err := c.dbRouter()
+	if err != nil {
+		return err
+	}
+
+	var rateLimits ratelimit.Store
+	if c.config.RateLimit.Enabled {
+		if rateLimits, err = c.rateLimits(); err != nil {
+			return err
+		}
+	}
+
+	listener, err := c.listener()
+	if err != nil {
+		return err
+	}
+
+	var adminListener net.Listener
+	if adminServer != nil {
+		if adminListener, err = c.adminListener(); err != nil {
+			return err
+		}
+	}
+
+	c.state.serving = true
+	errg, ctx := errgroup.WithContext(ctx)
+
+	serve(errg, c, "server", server, listener)
+
+	if adminServer != nil {
+		serve(errg, c, "admin server", adminServer, adminListener)
+	}
+
+	if router != nil {
+		c.goWorker("replica check", router.Run)
+	}
+
+	if store, ok := rateLimits.(*ratelimit.PostgresStore); ok {
+		c.goWorker("rate limit cleanup", func(ctx context.Context) {
+			cleanupRateLimits(ctx, c, store)
+		})
+	}
+
+	errg.Go(func() error {
+		<-ctx.Done()
+		return c.shutdown()
 	})
 
+	return errg.Wait()
+}
+
+// serve runs server until it's shut down.
+func serve(errg *errgroup.Group, c *container, name string, server *http.Server, listener net.Listener) {
+	addr := listener.Addr().String()
+	c.logger().Info(name+" started", zap.String("addr", addr))
+
 	errg.Go(func() error {
 		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
-			return err
+			return fmt.Errorf("%s: %w", name, err)
 		}
 
+		c.logger().Info(name+" stopped", zap.String("addr", addr))
 		return nil
 	})
+}
 
-	return errg.Wait()
+// cleanupRateLimits periodically removes expired rate limit buckets until ctx is done.
+func cleanupRateLimits(ctx context.Context, c *container, store *ratelimit.PostgresStore) {
+	ticker := time.NewTicker(c.config.RateLimit.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		removed, err := store.Cleanup(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				c.logger().Warn("rate limit cleanup", zap.Error(err))
+			}
+
+			continue
+		}
+
+		c.logger().Debug("rate limit cleanup", zap.Int64("removed", removed))
+	}
 }
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type shutdownStage struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// goWorker runs fn in background until workers are stopped during shutdown.
+func (c *container) goWorker(name string, fn func(ctx context.Context)) {
+	c.workers.wg.Add(1)
+
+	go func() {
+		defer c.workers.wg.Done()
+
+		c.logger().Debug("worker started", zap.String("worker", name))
+		fn(c.workers.ctx)
+		c.logger().Debug("worker stopped", zap.String("worker", name))
+	}()
+}
+
+// shutdown stops components in order so that in-flight requests complete before their dependencies are closed.
+// Every stage is bounded by shutdown timeout, stages continue after failure so that resources are released.
+// Components that were never created are skipped.
+func (c *container) shutdown() error {
+	stages := []shutdownStage{
+		// fail readiness before closing listener so that no new requests are routed here
+		{name: "readiness", fn: func(ctx context.Context) error {
+			if c.state.health != nil {
+				c.state.health.Shutdown()
+			}
+
+			return nil
+		}},
+		{name: "drain", fn: func(ctx context.Context) error {
+			// nothing to drain if startup failed before serving
+			if !c.state.serving {
+				return nil
+			}
+
+			select {
+			case <-time.After(c.config.Server.DrainDelay):
+			case <-ctx.Done():
+			}
+
+			return nil
+		}},
+		{name: "http", fn: func(ctx context.Context) error {
+			var err error
+
+			if c.state.httpServer != nil {
+				err = c.state.httpServer.Shutdown(ctx)
+			}
+
+			if c.state.adminServer != nil {
+				if aerr := c.state.adminServer.Shutdown(ctx); aerr != nil && err == nil {
+					err = aerr
+				}
+			}
+
+			return err
+		}},
+		{name: "workers", fn: func(ctx context.Context) error {
+			c.workers.cancel()
+			c.workers.wg.Wait()
+
+			return nil
+		}},
+		// flush spans of requests completed during shutdown
+		{name: "tracer", fn: func(ctx context.Context) error {
+			if c.state.tracer == nil {
+				return nil
+			}
+
+			return c.state.tracer.Shutdown(ctx)
+		}},
+		{name: "db", fn: func(ctx context.Context) error {
+			var err error
+			if c.state.db != nil {
+				err = c.state.db.Close()
+			}
+
+			// pool waits for acquired connections to be released
+			if c.state.pool != nil {
+				c.state.pool.Close()
+			}
+
+			if c.state.replica != nil {
+				c.state.replica.Close()
+			}
+
+			return err
+		}},
+		{name: "logger", fn: func(ctx context.Context) error {
+			err := c.logger().Sync()
+			// syncing stderr and stdout fails on some platforms
+			if errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTTY) {
+				return nil
+			}
+
+			return err
+		}},
+	}
+
+	var err error
+
+	for _, stage := range stages {
+		if serr := c.shutdownStage(stage); serr != nil && err == nil {
+			err = serr
+		}
+	}
+
+	return err
+}
+
+func (c *container) shutdownStage(stage shutdownStage) error {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Server.ShutdownTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- stage.fn(ctx)
+	}()
+
+	var err error
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		c.logger().Error("shutdown stage failed", zap.String("stage", stage.name), zap.Duration("duration", time.Since(start)), zap.Error(err))
+		return fmt.Errorf("%s shutdown: %w", stage.name, err)
+	}
+
+	c.logger().Info("shutdown stage completed", zap.String("stage", stage.name), zap.Duration("duration", time.Since(start)))
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Package apidoc serves OpenAPI specification and API explorer.
+package apidoc
+
+import (
+	"embed"
+	"fmt"
+	"net/http"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+)
+
+const (
+	JSONPath     = "/api/v1/openapi.json"
+	YAMLPath     = "/openapi.yaml"
+	ExplorerPath = "/docs"
+)
+
+// explorer assets are embedded so that explorer works offline and under strict content security policy.
+// Swagger UI is copied from swagger-ui-dist package.
+//
+//go:embed explorer.html explorer.js swagger-ui/swagger-ui-bundle.js swagger-ui/swagger-ui.css
+var explorerFS embed.FS
+
+// explorerAssets are served at ExplorerPath.
+var explorerAssets = []struct {
+	path        string
+	file        string
+	contentType string
+}{
+	{path: ExplorerPath, file: "explorer.html", contentType: "text/html; charset=utf-8"},
+	{path: ExplorerPath + "/explorer.js", file: "explorer.js", contentType: "text/javascript; charset=utf-8"},
+	{path: ExplorerPath + "/swagger-ui-bundle.js", file: "swagger-ui/swagger-ui-bundle.js", contentType: "text/javascript; charset=utf-8"},
+	{path: ExplorerPath + "/swagger-ui.css", file: "swagger-ui/swagger-ui.css", contentType: "text/css; charset=utf-8"},
+}
+
+// Server serves OpenAPI specification in JSON and YAML formats.
+type Server struct {
+	yaml     []byte
+	json     []byte
+	explorer bool
+}
+
+type Opt func(*Server)
+
+// Explorer enables API explorer UI at ExplorerPath.
+func Explorer(enabled bool) Opt {
+	return func(s *Server) {
+		s.explorer = enabled
+	}
+}
+
+// New creates server for OpenAPI spec in YAML format.
+func New(spec []byte, opts ...Opt) (*Server, error) {
+	json, err := yaml.YAMLToJSON(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert spec to JSON: %w", err)
+	}
+
+	s := &Server{
+		yaml: spec,
+		json: json,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+func (s *Server) RegisterRoutes(router *routes.Mux) {
+	router.Handler(http.MethodGet, JSONPath, s.content("application/json", s.json))
+	router.Handler(http.MethodGet, YAMLPath, s.content("application/yaml", s.yaml))
+
+	if !s.explorer {
+		return
+	}
+
+	for _, asset := range explorerAssets {
+		data, err := explorerFS.ReadFile(asset.file)
+		if err != nil {
+			// assets are embedded, missing asset is a programming error
+			panic(fmt.Sprintf("apidoc: %v", err))
+		}
+
+		router.Handler(http.MethodGet, asset.path, s.content(asset.contentType, data))
+	}
+}
+
+func (s *Server) content(contentType string, data []byte) routes.Handler {
+	return func(w http.ResponseWriter, req *http.Request) error {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+
+		_, err := w.Write(data)
+		return err
+	}
+}
@@ -0,0 +1,9 @@
+// Package blob stores binary contents such as attachments outside of the database.
+package blob
+
+import (
+	"errors"
+)
+
+// ErrNotFound is returned when opening blob that doesn't exist.
+var ErrNotFound = errors.New("blob not found")
@@ -0,0 +1,147 @@
+package blob_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/blob"
+)
+
+type store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// testStore checks behavior shared by store implementations.
+func testStore(t *testing.T, s store) {
+	ctx := context.Background()
+
+	t.Run("put and read", func(t *testing.T) {
+		put(t, s, "todo/1/attachment", "hello world")
+
+		r := open(t, s, "todo/1/attachment")
+		defer r.Close()
+
+		if actual := read(t, r); actual != "hello world" {
+			t.Errorf("expected %q, got %q", "hello world", actual)
+		}
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		put(t, s, "todo/2/attachment", "first")
+		put(t, s, "todo/2/attachment", "second")
+
+		r := open(t, s, "todo/2/attachment")
+		defer r.Close()
+
+		if actual := read(t, r); actual != "second" {
+			t.Errorf("expected %q, got %q", "second", actual)
+		}
+	})
+
+	t.Run("seek", func(t *testing.T) {
+		put(t, s, "todo/3/attachment", "hello world")
+
+		r := open(t, s, "todo/3/attachment")
+		defer r.Close()
+
+		tests := []struct {
+			offset   int64
+			whence   int
+			expected string
+		}{
+			{offset: 6, whence: io.SeekStart, expected: "world"},
+			{offset: -5, whence: io.SeekEnd, expected: "world"},
+			{offset: 0, whence: io.SeekStart, expected: "hello world"},
+		}
+
+		for _, tt := range tests {
+			if _, err := r.Seek(tt.offset, tt.whence); err != nil {
+				t.Fatal(err)
+			}
+
+			if actual := read(t, r); actual != tt.expected {
+				t.Errorf("expected %q after seek to %d from %d, got %q", tt.expected, tt.offset, tt.whence, actual)
+			}
+		}
+	})
+
+	t.Run("open missing", func(t *testing.T) {
+		if _, err := s.Open(ctx, "todo/missing/attachment"); !errors.Is(err, blob.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		put(t, s, "todo/4/attachment", "hello world")
+
+		for i := 0; i < 2; i++ {
+			if err := s.Delete(ctx, "todo/4/attachment"); err != nil {
+				t.Fatalf("expected delete %d to succeed: %v", i, err)
+			}
+		}
+
+		if _, err := s.Open(ctx, "todo/4/attachment"); !errors.Is(err, blob.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			content string
+			size    int64
+		}{
+			{name: "shorter", content: "hello", size: 11},
+			{name: "longer", content: "hello world", size: 5},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				key := "todo/" + tt.name + "/attachment"
+
+				if err := s.Put(ctx, key, strings.NewReader(tt.content), tt.size, "text/plain"); err == nil {
+					t.Fatal("expected put to fail")
+				}
+
+				if _, err := s.Open(ctx, key); !errors.Is(err, blob.ErrNotFound) {
+					t.Errorf("expected blob not to be stored, got %v", err)
+				}
+			})
+		}
+	})
+}
+
+func put(t *testing.T, s store, key, content string) {
+	t.Helper()
+
+	if err := s.Put(context.Background(), key, strings.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func open(t *testing.T, s store, key string) io.ReadSeekCloser {
+	t.Helper()
+
+	r, err := s.Open(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func read(t *testing.T, r io.Reader) string {
+	t.Helper()
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,112 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore keeps blobs as files in a directory, keys are slash separated paths relative to the directory.
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates dir if it doesn't exist.
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	return &LocalStore{
+		dir: dir,
+	}, nil
+}
+
+// Put writes blob to a temporary file which is renamed once complete, so that readers never see partial blobs.
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to put blob %q: %w", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".put-*")
+	if err != nil {
+		return fmt.Errorf("failed to put blob %q: %w", key, err)
+	}
+
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	n, err := io.Copy(tmp, r)
+	switch {
+	case err != nil:
+		return fmt.Errorf("failed to put blob %q: %w", key, err)
+	case n != size:
+		return fmt.Errorf("failed to put blob %q: expected %d bytes, got %d", key, size, n)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("failed to put blob %q: %w", key, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to put blob %q: %w", key, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to put blob %q: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *LocalStore) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("failed to open blob %q: %w", key, ErrNotFound)
+	case err != nil:
+		return nil, fmt.Errorf("failed to open blob %q: %w", key, err)
+	}
+
+	return f, nil
+}
+
+// Delete removes blob, missing blob is not an error.
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob %q: %w", key, err)
+	}
+
+	return nil
+}
+
+// path rejects keys escaping the directory.
+func (s *LocalStore) path(key string) (string, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,112 @@
+package blob_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/blob"
+)
+
+func TestLocalStore(t *testing.T) {
+	s, err := blob.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testStore(t, s)
+}
+
+func TestLocalStoreKeys(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := blob.NewLocalStore(filepath.Join(dir, "blobs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"", ".", "..", "../escaped", "todo/../../escaped", "/escaped", "todo//attachment", "todo/"} {
+		if err := s.Put(ctx, key, strings.NewReader("escaped"), 7, "text/plain"); err == nil {
+			t.Errorf("expected put of %q to fail", key)
+		}
+
+		if _, err := s.Open(ctx, key); err == nil || errors.Is(err, blob.ErrNotFound) {
+			t.Errorf("expected open of %q to fail as invalid, got %v", key, err)
+		}
+
+		if err := s.Delete(ctx, key); err == nil {
+			t.Errorf("expected delete of %q to fail", key)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "escaped")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected no file outside of store directory, got %v", err)
+	}
+}
+
+func TestLocalStorePut(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	errFailed := errors.New("failed")
+
+	s, err := blob.NewLocalStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	put(t, s, "todo/attachment", "hello world")
+
+	tests := []struct {
+		name string
+		r    io.Reader
+		size int64
+	}{
+		{name: "failed read", r: io.MultiReader(strings.NewReader("partial"), failingReader{err: errFailed}), size: 11},
+		{name: "shorter", r: strings.NewReader("partial"), size: 11},
+		{name: "longer", r: strings.NewReader("partial content"), size: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Put(ctx, "todo/attachment", tt.r, tt.size, "text/plain"); err == nil {
+				t.Fatal("expected put to fail")
+			}
+
+			r := open(t, s, "todo/attachment")
+			defer r.Close()
+
+			if actual := read(t, r); actual != "hello world" {
+				t.Errorf("expected previous contents to be kept, got %q", actual)
+			}
+
+			entries, err := os.ReadDir(filepath.Join(dir, "todo"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+
+			if diff := cmp.Diff([]string{"attachment"}, names); diff != "" {
+				t.Error("expected temporary files to be removed:", diff)
+			}
+		})
+	}
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r failingReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,220 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const defaultRegion = "us-east-1"
+
+// S3Store keeps blobs in a bucket of S3 compatible storage such as MinIO.
+// Objects are addressed path-style so that bucket names don't need to be valid host names.
+type S3Store struct {
+	client *minio.Client
+	bucket string
+}
+
+type s3Options struct {
+	region    string
+	accessKey string
+	secretKey string
+	transport http.RoundTripper
+}
+
+type S3Opt func(*s3Options)
+
+// Region used for signing requests, us-east-1 by default.
+func Region(region string) S3Opt {
+	return func(o *s3Options) {
+		o.region = region
+	}
+}
+
+// Credentials sign requests, requests are anonymous without credentials.
+func Credentials(accessKey, secretKey string) S3Opt {
+	return func(o *s3Options) {
+		o.accessKey = accessKey
+		o.secretKey = secretKey
+	}
+}
+
+// Transport sends requests, minio.DefaultTransport with connect and response header timeouts by default.
+// Transport should not limit duration of the whole request as blobs are streamed.
+func Transport(transport http.RoundTripper) S3Opt {
+	return func(o *s3Options) {
+		o.transport = transport
+	}
+}
+
+// NewS3Store creates store of objects in bucket at endpoint URL such as http://localhost:9000, bucket should exist.
+func NewS3Store(endpoint, bucket string, opts ...S3Opt) (*S3Store, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint %q: %w", endpoint, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q: expected http or https scheme", endpoint)
+	}
+
+	if strings.Trim(u.Path, "/") != "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q: path is not supported", endpoint)
+	}
+
+	if bucket == "" {
+		return nil, errors.New("s3 bucket is not set")
+	}
+
+	o := s3Options{
+		region: defaultRegion,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	client, err := minio.New(u.Host, &minio.Options{
+		// empty keys send anonymous requests
+		Creds:        credentials.NewStaticV4(o.accessKey, o.secretKey, ""),
+		Secure:       u.Scheme == "https",
+		Transport:    o.transport,
+		Region:       o.region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint %q: %w", endpoint, err)
+	}
+
+	return &S3Store{
+		client: client,
+		bucket: bucket,
+	}, nil
+}
+
+// Put uploads exactly size bytes, object is stored only if upload completes.
+// Reader is not rewound so upload that fails after it was read is not retried.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+
+	body := &sizedReader{r: r, size: size}
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put blob %q: %w", key, err)
+	}
+
+	// body ends after size bytes, remaining bytes mean that size was wrong
+	if _, err := io.ReadFull(r, make([]byte, 1)); err == nil {
+		if err := s.Delete(ctx, key); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("failed to put blob %q: expected %d bytes, got more", key, size)
+	}
+
+	return nil
+}
+
+// Open checks that object exists, its contents are requested on first read from the current offset.
+func (s *S3Store) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob %q: %w", key, err)
+	}
+
+	if _, err := obj.Stat(); err != nil {
+		_ = obj.Close()
+
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("failed to open blob %q: %w", key, ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("failed to open blob %q: %w", key, err)
+	}
+
+	return obj, nil
+}
+
+// Delete removes object, missing object is not an error.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete blob %q: %w", key, err)
+	}
+
+	return nil
+}
+
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return nil
+}
+
+// sizedReader reads up to size bytes and reports reader ending early as an error.
+// Client retries failed requests after seeking to the start, seeking fails once reading started as reader can't be rewound.
+type sizedReader struct {
+	r    io.Reader
+	size int64
+	read int64
+	err  error
+}
+
+func (r *sizedReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	if r.read >= r.size {
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > r.size-r.read {
+		p = p[:r.size-r.read]
+	}
+
+	n, err := r.r.Read(p)
+	r.read += int64(n)
+
+	if errors.Is(err, io.EOF) && r.read < r.size {
+		err = fmt.Errorf("expected %d bytes, got %d: %w", r.size, r.read, io.ErrUnexpectedEOF)
+	}
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		r.err = err
+	}
+
+	return n, err
+}
+
+func (r *sizedReader) Seek(offset int64, whence int) (int64, error) {
+	switch {
+	case r.err != nil:
+		return 0, r.err
+	case offset == 0 && whence == io.SeekStart && r.read == 0:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("upload can't be retried after %d bytes were read", r.read)
+	}
+}
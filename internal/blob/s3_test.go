@@ -0,0 +1,14 @@
+//go:build integration
+// +build integration
+
+package blob_test
+
+import (
+	"testing"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/blobtest"
+)
+
+func TestS3Store(t *testing.T) {
+	testStore(t, blobtest.SetupMinIO(t).Store(t))
+}
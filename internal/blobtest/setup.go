@@ -0,0 +1,110 @@
+//go:build integration
+// +build integration
+
+// Package blobtest starts S3 compatible storage in docker for integration tests.
+package blobtest
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v3"
+	"github.com/ory/dockertest/v3"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/blob"
+)
+
+const (
+	accessKey = "blobtest"
+	secretKey = "blobtest-secret"
+	bucket    = "attachments"
+)
+
+// S3 describes storage started by SetupMinIO.
+type S3 struct {
+	Endpoint  string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// Store returns store of the bucket.
+func (s S3) Store(t testing.TB) *blob.S3Store {
+	t.Helper()
+
+	store, err := blob.NewS3Store(s.Endpoint, s.Bucket, blob.Credentials(s.AccessKey, s.SecretKey))
+	if err != nil {
+		t.Fatalf("blobtest: create store: %v", err)
+	}
+
+	return store
+}
+
+// SetupMinIO starts MinIO with an empty bucket, it waits until objects can be stored in the bucket.
+func SetupMinIO(t testing.TB) S3 {
+	t.Helper()
+
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		t.Fatalf("blobtest: create pool: %v", err)
+	}
+
+	suffix, _ := rand.Int(rand.Reader, big.NewInt(100000))
+	name := fmt.Sprintf("minio-%s", suffix)
+
+	// bitnami image creates buckets on startup
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Name:       name,
+		Repository: "bitnami/minio",
+		Tag:        "2022",
+		Env: []string{
+			"MINIO_ROOT_USER=" + accessKey,
+			"MINIO_ROOT_PASSWORD=" + secretKey,
+			"MINIO_DEFAULT_BUCKETS=" + bucket,
+		},
+	})
+	if err != nil {
+		t.Fatalf("blobtest: start minio: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Errorf("blobtest: purge pool: %v", err)
+		}
+	})
+
+	t.Logf("blobtest: started container %q", name)
+
+	s3 := S3{
+		Endpoint:  fmt.Sprintf("http://%s:%s", resource.GetBoundIP("9000/tcp"), resource.GetPort("9000/tcp")),
+		Bucket:    bucket,
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+	}
+
+	store := s3.Store(t)
+	ctx := context.Background()
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = 100 * time.Millisecond
+	bo.MaxInterval = time.Second
+	bo.MaxElapsedTime = 30 * time.Second
+
+	err = backoff.Retry(func() error {
+		return store.Put(ctx, "ready", strings.NewReader(""), 0, "text/plain")
+	}, bo)
+	if err != nil {
+		t.Fatalf("blobtest: wait for minio: %v", err)
+	}
+
+	if err := store.Delete(ctx, "ready"); err != nil {
+		t.Fatalf("blobtest: wait for minio: %v", err)
+	}
+
+	return s3
+}
@@ -0,0 +1,359 @@
+// Package configfile loads envconfig specifications from YAML or JSON files.
+//
+// File keys follow json tags of the specification while environment variable names follow envconfig tags.
+// Defaults and environment variables are processed by envconfig, file values replace defaults of fields without environment variable set.
+// File values are formatted as strings and parsed the way envconfig parses environment variables so that every type is read the same way regardless of the source.
+package configfile
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/kelseyhightower/envconfig"
+)
+
+const redacted = "xxxxx"
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+
+	dsnPassword = regexp.MustCompile(`(password=)('[^']*'|\S+)`)
+)
+
+// Load populates spec from optional file and environment variables.
+// Environment variables take precedence over the file which takes precedence over defaults.
+func Load(prefix, file string, spec interface{}) error {
+	if err := envconfig.Process(prefix, spec); err != nil {
+		return err
+	}
+
+	if file == "" {
+		return nil
+	}
+
+	values, err := read(file)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(spec)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return envconfig.ErrInvalidSpecification
+	}
+
+	// file values replace defaults set by envconfig unless environment variable is set
+	if err := apply(v.Elem(), strings.ToUpper(prefix), "", values); err != nil {
+		return fmt.Errorf("invalid config file %q: %w", file, err)
+	}
+
+	return nil
+}
+
+func read(file string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// JSON is valid YAML so both formats are read the same way
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %q: %w", file, err)
+	}
+
+	var values map[string]interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if err := dec.Decode(&values); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %q: expected mapping: %w", file, err)
+	}
+
+	return values, nil
+}
+
+// apply decodes file values into fields of v which are not set by environment variables.
+func apply(v reflect.Value, prefix, path string, values map[string]interface{}) error {
+	for name, value := range values {
+		field, ok := fieldByJSONName(v.Type(), name)
+		if !ok {
+			return fmt.Errorf("unknown key %s", path+name)
+		}
+
+		key := envKey(prefix, field)
+
+		if nested(field.Type) {
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("key %s should be a mapping", path+name)
+			}
+
+			if err := apply(v.FieldByIndex(field.Index), key, path+name+".", m); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		s, err := scalar(value)
+		if err != nil {
+			return fmt.Errorf("key %s %w", path+name, err)
+		}
+
+		if fromEnv(key, field) {
+			continue
+		}
+
+		if err := decode(v.FieldByIndex(field.Index), s); err != nil {
+			return fmt.Errorf("key %s: %w", path+name, err)
+		}
+	}
+
+	return nil
+}
+
+// fromEnv reports whether envconfig read field from environment variable key or its unprefixed name.
+func fromEnv(key string, field reflect.StructField) bool {
+	if _, ok := os.LookupEnv(key); ok {
+		return true
+	}
+
+	if name := field.Tag.Get("envconfig"); name != "" {
+		_, ok := os.LookupEnv(strings.ToUpper(name))
+		return ok
+	}
+
+	return false
+}
+
+// decode parses value into field the same way envconfig parses environment variables.
+func decode(field reflect.Value, value string) error {
+	switch v := field.Addr().Interface().(type) {
+	case envconfig.Decoder:
+		return v.Decode(value)
+	case envconfig.Setter:
+		return v.Set(value)
+	case encoding.TextUnmarshaler:
+		return v.UnmarshalText([]byte(value))
+	}
+
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(f)
+	case reflect.Slice:
+		items := reflect.MakeSlice(field.Type(), 0, 0)
+		if strings.TrimSpace(value) != "" {
+			parts := strings.Split(value, ",")
+			items = reflect.MakeSlice(field.Type(), len(parts), len(parts))
+
+			for i, part := range parts {
+				if err := decode(items.Index(i), part); err != nil {
+					return err
+				}
+			}
+		}
+
+		field.Set(items)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
+
+// scalar formats value as envconfig expects it, lists are comma separated.
+func scalar(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, err := scalar(item)
+			if err != nil {
+				return "", err
+			}
+
+			items[i] = s
+		}
+
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("should be a scalar or a list")
+	}
+}
+
+// Print writes spec as YAML accepted by Load, fields tagged with secret:"true" are redacted.
+func Print(w io.Writer, spec interface{}) error {
+	data, err := yaml.Marshal(toMap(reflect.Indirect(reflect.ValueOf(spec))))
+	if err != nil {
+		return fmt.Errorf("failed to print config: %w", err)
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+func toMap(v reflect.Value) map[string]interface{} {
+	t := v.Type()
+	m := make(map[string]interface{}, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Tag.Get("ignored") == "true" {
+			continue
+		}
+
+		name := jsonName(field)
+
+		switch {
+		case nested(field.Type):
+			m[name] = toMap(v.Field(i))
+		case field.Tag.Get("secret") == "true":
+			m[name] = redact(fmt.Sprint(v.Field(i).Interface()))
+		default:
+			m[name] = value(v.Field(i))
+		}
+	}
+
+	return m
+}
+
+func value(v reflect.Value) interface{} {
+	switch {
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String()
+	case v.Type().Implements(textMarshalerType):
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return err.Error()
+		}
+
+		return string(text)
+	case v.Kind() == reflect.Slice:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = value(v.Index(i))
+		}
+
+		return items
+	default:
+		return v.Interface()
+	}
+}
+
+// redact hides password of URL or key/value data source names, other secrets are hidden entirely.
+func redact(secret string) string {
+	switch {
+	case secret == "":
+		return ""
+	case strings.Contains(secret, "://"):
+		u, err := url.Parse(secret)
+		if err != nil {
+			return redacted
+		}
+
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+
+		return u.String()
+	case strings.Contains(secret, "="):
+		return dsnPassword.ReplaceAllString(secret, "${1}"+redacted)
+	default:
+		return redacted
+	}
+}
+
+// nested reports whether envconfig processes fields of t separately.
+func nested(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+func envKey(prefix string, field reflect.StructField) string {
+	name := field.Tag.Get("envconfig")
+	if name == "" {
+		name = field.Name
+	}
+
+	return strings.ToUpper(prefix + "_" + name)
+}
+
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath == "" && jsonName(field) == name {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,130 @@
+package configfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/configfile"
+)
+
+type spec struct {
+	Name    string        `json:"name" envconfig:"SERVICE_NAME" default:"todo"`
+	Timeout time.Duration `json:"timeout" envconfig:"REQUEST_TIMEOUT" default:"5s"`
+	Server  struct {
+		Port    int      `json:"port" envconfig:"LISTEN_PORT" default:"8080"`
+		Origins []string `json:"origins" envconfig:"ALLOWED_ORIGINS" default:"https://example.com"`
+		Debug   bool     `json:"debug" envconfig:"DEBUG_LOGS"`
+	} `json:"server" envconfig:"SERVER"`
+}
+
+func TestLoad(t *testing.T) {
+	file := writeFile(t, `
+timeout: 3s
+server:
+  port: 9090
+  origins: [https://example.org, https://example.net]
+  debug: true
+`)
+
+	setenv(t, "TEST_SERVER_LISTEN_PORT", "7070")
+
+	var actual spec
+	if err := configfile.Load("TEST", file, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	var expected spec
+	expected.Name = "todo"
+	expected.Timeout = 3 * time.Second
+	expected.Server.Port = 7070
+	expected.Server.Origins = []string{"https://example.org", "https://example.net"}
+	expected.Server.Debug = true
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Error("expected defaults overridden by file and environment:", diff)
+	}
+
+	for _, key := range []string{"TEST_REQUEST_TIMEOUT", "TEST_SERVER_ALLOWED_ORIGINS", "TEST_SERVER_DEBUG_LOGS"} {
+		if value, ok := os.LookupEnv(key); ok {
+			t.Errorf("expected %s not to be set, got %q", key, value)
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "unknown key", content: "server:\n  unknown: true\n", err: "unknown key server.unknown"},
+		{name: "mapping expected", content: "server: 8080\n", err: "key server should be a mapping"},
+		{name: "scalar expected", content: "name:\n  first: todo\n", err: "key name should be a scalar or a list"},
+		{name: "invalid value", content: "server:\n  port: http\n", err: "key server.port: "},
+		{name: "invalid duration", content: "timeout: 10\n", err: "key timeout: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s spec
+			if err := configfile.Load("TEST", writeFile(t, tt.content), &s); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	var s spec
+	if err := configfile.Load("TEST", "", &s); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := configfile.Print(&out, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	var actual spec
+	if err := configfile.Load("TEST", writeFile(t, out.String()), &actual); err != nil {
+		t.Fatalf("expected printed config to be loaded: %v", err)
+	}
+
+	if diff := cmp.Diff(s, actual); diff != "" {
+		t.Error("expected printed config to round trip:", diff)
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+// setenv sets environment variable for the duration of test, testing.T.Setenv requires Go 1.17.
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+
+	prev, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, prev)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
@@ -0,0 +1,306 @@
+// Package cors implements Cross-Origin Resource Sharing for browser clients.
+package cors
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+)
+
+const (
+	headerOrigin           = "Origin"
+	headerVary             = "Vary"
+	headerRequestMethod    = "Access-Control-Request-Method"
+	headerRequestHeaders   = "Access-Control-Request-Headers"
+	headerAllowOrigin      = "Access-Control-Allow-Origin"
+	headerAllowMethods     = "Access-Control-Allow-Methods"
+	headerAllowHeaders     = "Access-Control-Allow-Headers"
+	headerAllowCredentials = "Access-Control-Allow-Credentials"
+	headerExposeHeaders    = "Access-Control-Expose-Headers"
+	headerMaxAge           = "Access-Control-Max-Age"
+
+	wildcard = "*"
+)
+
+type CORS struct {
+	logger           *zap.Logger
+	anyOrigin        bool
+	origins          []origin
+	methods          []string
+	headers          []string
+	anyHeader        bool
+	exposedHeaders   []string
+	allowCredentials bool
+	maxAge           time.Duration
+}
+
+type Opt func(*CORS)
+
+func Logger(logger *zap.Logger) Opt {
+	return func(c *CORS) {
+		c.logger = logger
+	}
+}
+
+// AllowedOrigins sets origins allowed to make requests.
+// Origin is either exact such as https://example.com, wildcard subdomain such as https://*.example.com or * to allow any origin.
+func AllowedOrigins(origins ...string) Opt {
+	return func(c *CORS) {
+		c.anyOrigin = false
+		c.origins = c.origins[:0]
+
+		for _, o := range origins {
+			o = strings.ToLower(strings.TrimSpace(o))
+			switch {
+			case o == "":
+				continue
+			case o == wildcard:
+				c.anyOrigin = true
+			default:
+				c.origins = append(c.origins, parseOrigin(o))
+			}
+		}
+	}
+}
+
+// AllowedMethods sets methods allowed in preflight requests.
+func AllowedMethods(methods ...string) Opt {
+	return func(c *CORS) {
+		c.methods = normalize(methods, strings.ToUpper)
+	}
+}
+
+// AllowedHeaders sets request headers allowed in preflight requests, * allows any header.
+func AllowedHeaders(headers ...string) Opt {
+	return func(c *CORS) {
+		c.headers = normalize(headers, http.CanonicalHeaderKey)
+		c.anyHeader = contains(c.headers, wildcard)
+	}
+}
+
+// ExposedHeaders sets response headers readable by browser clients.
+func ExposedHeaders(headers ...string) Opt {
+	return func(c *CORS) {
+		c.exposedHeaders = normalize(headers, http.CanonicalHeaderKey)
+	}
+}
+
+// AllowCredentials allows requests with cookies and authorization headers.
+func AllowCredentials(allow bool) Opt {
+	return func(c *CORS) {
+		c.allowCredentials = allow
+	}
+}
+
+// MaxAge sets how long browsers cache preflight responses, zero leaves it to browser default.
+func MaxAge(maxAge time.Duration) Opt {
+	return func(c *CORS) {
+		c.maxAge = maxAge
+	}
+}
+
+func New(opts ...Opt) *CORS {
+	c := &CORS{
+		logger:  zap.NewNop(),
+		methods: []string{http.MethodGet, http.MethodHead, http.MethodPost},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Handler answers preflight requests and adds CORS headers to responses of next.
+// It wraps router rather than individual routes so that preflight requests are answered for every route.
+// Disallowed preflight requests are rejected with 403 Forbidden.
+func (c *CORS) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		origin := req.Header.Get(headerOrigin)
+		if origin == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		if req.Method == http.MethodOptions && req.Header.Get(headerRequestMethod) != "" {
+			c.preflight(w, req, origin)
+			return
+		}
+
+		header := w.Header()
+		header.Add(headerVary, headerOrigin)
+
+		if c.allowOrigin(origin) {
+			c.setAllowOrigin(header, origin)
+
+			if len(c.exposedHeaders) > 0 {
+				header.Set(headerExposeHeaders, strings.Join(c.exposedHeaders, ", "))
+			}
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+func (c *CORS) preflight(w http.ResponseWriter, req *http.Request, origin string) {
+	header := w.Header()
+	header.Add(headerVary, headerOrigin)
+	header.Add(headerVary, headerRequestMethod)
+	header.Add(headerVary, headerRequestHeaders)
+
+	method := strings.ToUpper(req.Header.Get(headerRequestMethod))
+	headers := parseHeaders(req.Header.Values(headerRequestHeaders))
+
+	reject := func(reason string) {
+		logging.Logger(req.Context(), c.logger).Warn("cors preflight rejected",
+			zap.String("reason", reason),
+			zap.String("origin", origin),
+			zap.String("method", method),
+			zap.Strings("headers", headers),
+			zap.String("path", req.URL.Path),
+		)
+
+		w.WriteHeader(http.StatusForbidden)
+	}
+
+	if !c.allowOrigin(origin) {
+		reject("origin not allowed")
+		return
+	}
+
+	if !contains(c.methods, method) {
+		reject("method not allowed")
+		return
+	}
+
+	for _, h := range headers {
+		if !c.anyHeader && !contains(c.headers, h) {
+			reject("header " + h + " not allowed")
+			return
+		}
+	}
+
+	c.setAllowOrigin(header, origin)
+	header.Set(headerAllowMethods, strings.Join(c.methods, ", "))
+
+	if len(headers) > 0 {
+		header.Set(headerAllowHeaders, strings.Join(headers, ", "))
+	}
+
+	if c.maxAge > 0 {
+		header.Set(headerMaxAge, strconv.Itoa(int(c.maxAge.Seconds())))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setAllowOrigin echoes origin unless any origin is allowed without credentials.
+// Wildcard can't be used with credentials so that the origin is echoed in that case too.
+func (c *CORS) setAllowOrigin(header http.Header, origin string) {
+	if c.anyOrigin && !c.allowCredentials {
+		header.Set(headerAllowOrigin, wildcard)
+	} else {
+		header.Set(headerAllowOrigin, origin)
+	}
+
+	if c.allowCredentials {
+		header.Set(headerAllowCredentials, "true")
+	}
+}
+
+func (c *CORS) allowOrigin(raw string) bool {
+	if c.anyOrigin {
+		return true
+	}
+
+	o := parseOrigin(strings.ToLower(raw))
+	for _, allowed := range c.origins {
+		if allowed.match(o) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// origin is split into parts so that wildcard subdomains can't match a different scheme or port.
+type origin struct {
+	scheme string
+	host   string
+	port   string
+	// subdomains matches any subdomain of host but not the host itself, host starts with dot in that case
+	subdomains bool
+}
+
+func parseOrigin(raw string) origin {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		// unparsable origins still match exactly
+		return origin{host: raw}
+	}
+
+	o := origin{
+		scheme: u.Scheme,
+		host:   u.Hostname(),
+		port:   u.Port(),
+	}
+
+	if strings.HasPrefix(o.host, "*.") {
+		o.host = o.host[1:]
+		o.subdomains = true
+	}
+
+	return o
+}
+
+func (o origin) match(other origin) bool {
+	if o.scheme != other.scheme || o.port != other.port {
+		return false
+	}
+
+	if o.subdomains {
+		return strings.HasSuffix(other.host, o.host)
+	}
+
+	return o.host == other.host
+}
+
+// parseHeaders splits comma separated header names.
+func parseHeaders(values []string) []string {
+	var headers []string
+
+	for _, value := range values {
+		headers = append(headers, normalize(strings.Split(value, ","), http.CanonicalHeaderKey)...)
+	}
+
+	return headers
+}
+
+func normalize(values []string, fn func(string) string) []string {
+	normalized := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			normalized = append(normalized, fn(v))
+		}
+	}
+
+	return normalized
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,194 @@
+package cors_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/cors"
+)
+
+func TestHandler(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	base := []cors.Opt{
+		cors.AllowedOrigins("https://example.com", "https://*.example.org"),
+		cors.AllowedMethods(http.MethodGet, http.MethodPost),
+		cors.AllowedHeaders("Content-Type", "X-Request-ID"),
+		cors.ExposedHeaders("X-Request-ID"),
+		cors.MaxAge(10 * time.Minute),
+	}
+
+	for _, tc := range []struct {
+		name           string
+		opts           []cors.Opt
+		method         string
+		origin         string
+		requestMethod  string
+		requestHeaders string
+		status         int
+		header         map[string]string
+	}{
+		{
+			name:   "same origin",
+			opts:   base,
+			method: http.MethodGet,
+			status: http.StatusOK,
+			header: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "allowed origin",
+			opts:   base,
+			method: http.MethodGet,
+			origin: "https://example.com",
+			status: http.StatusOK,
+			header: map[string]string{
+				"Access-Control-Allow-Origin":   "https://example.com",
+				"Access-Control-Expose-Headers": "X-Request-Id",
+				"Vary":                          "Origin",
+			},
+		},
+		{
+			name:   "disallowed origin",
+			opts:   base,
+			method: http.MethodGet,
+			origin: "https://example.net",
+			status: http.StatusOK,
+			header: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "wildcard subdomain",
+			opts:   base,
+			method: http.MethodGet,
+			origin: "https://app.eu.example.org",
+			status: http.StatusOK,
+			header: map[string]string{"Access-Control-Allow-Origin": "https://app.eu.example.org"},
+		},
+		{
+			name:   "wildcard subdomain excludes parent domain",
+			opts:   base,
+			method: http.MethodGet,
+			origin: "https://example.org",
+			status: http.StatusOK,
+			header: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "wildcard subdomain excludes other scheme",
+			opts:   base,
+			method: http.MethodGet,
+			origin: "http://app.example.org",
+			status: http.StatusOK,
+			header: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "wildcard subdomain excludes suffix match",
+			opts:   base,
+			method: http.MethodGet,
+			origin: "https://evilexample.org",
+			status: http.StatusOK,
+			header: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:           "allowed preflight",
+			opts:           base,
+			method:         http.MethodOptions,
+			origin:         "https://example.com",
+			requestMethod:  http.MethodPost,
+			requestHeaders: "content-type, x-request-id",
+			status:         http.StatusNoContent,
+			header: map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.com",
+				"Access-Control-Allow-Methods":     "GET, POST",
+				"Access-Control-Allow-Headers":     "Content-Type, X-Request-Id",
+				"Access-Control-Allow-Credentials": "",
+				"Access-Control-Max-Age":           "600",
+			},
+		},
+		{
+			name:          "preflight with disallowed origin",
+			opts:          base,
+			method:        http.MethodOptions,
+			origin:        "https://example.net",
+			requestMethod: http.MethodGet,
+			status:        http.StatusForbidden,
+			header:        map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:          "preflight with disallowed method",
+			opts:          base,
+			method:        http.MethodOptions,
+			origin:        "https://example.com",
+			requestMethod: http.MethodDelete,
+			status:        http.StatusForbidden,
+			header:        map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:           "preflight with disallowed header",
+			opts:           base,
+			method:         http.MethodOptions,
+			origin:         "https://example.com",
+			requestMethod:  http.MethodPost,
+			requestHeaders: "Authorization",
+			status:         http.StatusForbidden,
+			header:         map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:           "any origin and header",
+			opts:           []cors.Opt{cors.AllowedOrigins("*"), cors.AllowedHeaders("*")},
+			method:         http.MethodOptions,
+			origin:         "https://example.net",
+			requestMethod:  http.MethodGet,
+			requestHeaders: "Authorization",
+			status:         http.StatusNoContent,
+			header: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Headers": "Authorization",
+				"Access-Control-Max-Age":       "",
+			},
+		},
+		{
+			name:   "any origin with credentials",
+			opts:   []cors.Opt{cors.AllowedOrigins("*"), cors.AllowCredentials(true)},
+			method: http.MethodGet,
+			origin: "https://example.net",
+			status: http.StatusOK,
+			header: map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.net",
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/api/v1/todo", nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+
+			if tc.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tc.requestMethod)
+			}
+
+			if tc.requestHeaders != "" {
+				req.Header.Set("Access-Control-Request-Headers", tc.requestHeaders)
+			}
+
+			rec := httptest.NewRecorder()
+			cors.New(tc.opts...).Handler(next).ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, rec.Code)
+			}
+
+			for name, expected := range tc.header {
+				if actual := rec.Header().Get(name); actual != expected {
+					t.Errorf("expected header %s %q, got %q", name, expected, actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Package dberr translates constraint violations reported by the database to problems
+// so that rules enforced by the schema are reported to clients even if validation in Go drifts from them.
+package dberr
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+)
+
+// Postgres error codes of constraint violations.
+const (
+	codeStringDataRightTruncation = "22001"
+	codeForeignKeyViolation       = "23503"
+	codeUniqueViolation           = "23505"
+	codeCheckViolation            = "23514"
+)
+
+const sqliteCheckPrefix = "CHECK constraint failed: "
+
+type kind int
+
+const (
+	kindTruncation kind = iota + 1
+	kindForeignKey
+	kindUnique
+	kindCheck
+)
+
+type violation struct {
+	kind       kind
+	constraint string
+	column     string
+}
+
+// Mapper maps constraint violations to problems.
+type Mapper struct {
+	constraints map[string]problem.FieldError
+}
+
+type Opt func(*Mapper)
+
+// Constraint reports violation of named constraint as problem of field with message.
+func Constraint(name, field, message string) Opt {
+	return func(m *Mapper) {
+		m.constraints[name] = problem.FieldError{Field: field, Message: message}
+	}
+}
+
+func New(opts ...Opt) *Mapper {
+	m := &Mapper{
+		constraints: make(map[string]problem.FieldError),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Map returns problem for constraint violation in err chain, other errors are returned as is.
+// Check violations and values too long for a column are validation problems,
+// unique and foreign key violations are conflicts with the current state of the database.
+// Validation problems of constraints without registered field are reported for the column or constraint named by the database.
+func (m *Mapper) Map(err error) error {
+	v, ok := violationFrom(err)
+	if !ok {
+		return err
+	}
+
+	switch v.kind {
+	case kindTruncation:
+		return problem.Validation(m.opts(err, v, "value is too long")...)
+	case kindCheck:
+		return problem.Validation(m.opts(err, v, "value is not allowed")...)
+	case kindUnique:
+		return problem.Conflict(m.opts(err, v, "resource already exists")...)
+	default:
+		return problem.Conflict(m.opts(err, v, "referenced resource does not exist or is still referenced")...)
+	}
+}
+
+func (m *Mapper) opts(err error, v violation, detail string) []problem.Opt {
+	opts := []problem.Opt{problem.Cause(err), problem.Detail(detail)}
+
+	if fe, ok := m.constraints[v.constraint]; ok {
+		return append(opts, problem.Fields(fe))
+	}
+
+	if v.kind != kindTruncation && v.kind != kindCheck {
+		return opts
+	}
+
+	switch {
+	case v.column != "":
+		return append(opts, problem.Field(v.column, detail))
+	case v.constraint != "":
+		return append(opts, problem.Field(v.constraint, detail))
+	default:
+		return opts
+	}
+}
+
+func violationFrom(err error) (violation, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		v := violation{constraint: pgErr.ConstraintName, column: pgErr.ColumnName}
+
+		switch pgErr.Code {
+		case codeStringDataRightTruncation:
+			v.kind = kindTruncation
+		case codeForeignKeyViolation:
+			v.kind = kindForeignKey
+		case codeUniqueViolation:
+			v.kind = kindUnique
+		case codeCheckViolation:
+			v.kind = kindCheck
+		default:
+			return violation{}, false
+		}
+
+		return v, true
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.ExtendedCode {
+		case sqlite3.ErrConstraintCheck:
+			// sqlite reports only the name of violated check constraint in the message
+			return violation{
+				kind:       kindCheck,
+				constraint: strings.TrimPrefix(sqliteErr.Error(), sqliteCheckPrefix),
+			}, true
+		case sqlite3.ErrConstraintForeignKey:
+			return violation{kind: kindForeignKey}, true
+		case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+			return violation{kind: kindUnique}, true
+		}
+	}
+
+	return violation{}, false
+}
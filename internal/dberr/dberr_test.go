@@ -0,0 +1,142 @@
+package dberr_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jackc/pgconn"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dberr"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+)
+
+func TestMap(t *testing.T) {
+	mapper := dberr.New(
+		dberr.Constraint("todo_title_length", "title", "should be at most 20 characters"),
+		dberr.Constraint("todo_pkey", "id", "already exists"),
+	)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected *problem.Problem
+	}{
+		{
+			name: "unique violation",
+			err:  &pgconn.PgError{Code: "23505", ConstraintName: "todo_pkey"},
+			expected: &problem.Problem{
+				Type:   problem.TypeConflict,
+				Title:  "Conflict",
+				Status: http.StatusConflict,
+				Detail: "resource already exists",
+				Errors: []problem.FieldError{{Field: "id", Message: "already exists"}},
+			},
+		},
+		{
+			name: "unique violation of unregistered constraint",
+			err:  &pgconn.PgError{Code: "23505", ConstraintName: "todo_title_key"},
+			expected: &problem.Problem{
+				Type:   problem.TypeConflict,
+				Title:  "Conflict",
+				Status: http.StatusConflict,
+				Detail: "resource already exists",
+			},
+		},
+		{
+			name: "check violation",
+			err:  &pgconn.PgError{Code: "23514", ConstraintName: "todo_title_length"},
+			expected: &problem.Problem{
+				Type:   problem.TypeValidation,
+				Title:  "Validation failed",
+				Status: http.StatusBadRequest,
+				Detail: "value is not allowed",
+				Errors: []problem.FieldError{{Field: "title", Message: "should be at most 20 characters"}},
+			},
+		},
+		{
+			name: "check violation of unregistered constraint",
+			err:  &pgconn.PgError{Code: "23514", ConstraintName: "todo_content_length"},
+			expected: &problem.Problem{
+				Type:   problem.TypeValidation,
+				Title:  "Validation failed",
+				Status: http.StatusBadRequest,
+				Detail: "value is not allowed",
+				Errors: []problem.FieldError{{Field: "todo_content_length", Message: "value is not allowed"}},
+			},
+		},
+		{
+			name: "string data right truncation",
+			err:  fmt.Errorf("failed to create todo: %w", &pgconn.PgError{Code: "22001", ColumnName: "title"}),
+			expected: &problem.Problem{
+				Type:   problem.TypeValidation,
+				Title:  "Validation failed",
+				Status: http.StatusBadRequest,
+				Detail: "value is too long",
+				Errors: []problem.FieldError{{Field: "title", Message: "value is too long"}},
+			},
+		},
+		{
+			name: "string data right truncation without column",
+			err:  &pgconn.PgError{Code: "22001"},
+			expected: &problem.Problem{
+				Type:   problem.TypeValidation,
+				Title:  "Validation failed",
+				Status: http.StatusBadRequest,
+				Detail: "value is too long",
+			},
+		},
+		{
+			name: "foreign key violation",
+			err:  &pgconn.PgError{Code: "23503", ConstraintName: "todo_attachment_todo_id_fkey"},
+			expected: &problem.Problem{
+				Type:   problem.TypeConflict,
+				Title:  "Conflict",
+				Status: http.StatusConflict,
+				Detail: "referenced resource does not exist or is still referenced",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mapper.Map(tt.err)
+
+			var actual *problem.Problem
+			if !errors.As(err, &actual) {
+				t.Fatalf("expected problem, got %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expected, actual, cmpopts.IgnoreUnexported(problem.Problem{})); diff != "" {
+				t.Error("expected equal problem:", diff)
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected problem caused by %v", tt.err)
+			}
+		})
+	}
+}
+
+func TestMapOther(t *testing.T) {
+	mapper := dberr.New()
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}},
+		{name: "not a database error", err: errors.New("failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := mapper.Map(tt.err); err != tt.err {
+				t.Errorf("expected error to be returned as is, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package dbrouter
+
+import (
+	"fmt"
+)
+
+// LSN is Postgres write-ahead log location.
+type LSN uint64
+
+// ParseLSN parses LSN in Postgres text format such as 16/B374D848.
+func ParseLSN(s string) (LSN, error) {
+	var hi, lo uint32
+	if _, err := fmt.Sscanf(s, "%X/%X", &hi, &lo); err != nil {
+		return 0, fmt.Errorf("invalid lsn %q: %w", s, err)
+	}
+
+	return LSN(uint64(hi)<<32 | uint64(lo)), nil
+}
+
+func (l LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint32(l>>32), uint32(l))
+}
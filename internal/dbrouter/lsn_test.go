@@ -0,0 +1,50 @@
+package dbrouter_test
+
+import (
+	"testing"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbrouter"
+)
+
+func TestParseLSN(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected dbrouter.LSN
+		err      bool
+	}{
+		{raw: "0/0", expected: 0},
+		{raw: "0/16B3748", expected: 0x16B3748},
+		{raw: "16/B374D848", expected: 0x16B374D848},
+		{raw: "16/b374d848", expected: 0x16B374D848},
+		{raw: "FFFFFFFF/FFFFFFFF", expected: 0xFFFFFFFFFFFFFFFF},
+		{raw: "", err: true},
+		{raw: "16", err: true},
+		{raw: "16-B374D848", err: true},
+		{raw: "G/0", err: true},
+	}
+
+	for _, tt := range tests {
+		actual, err := dbrouter.ParseLSN(tt.raw)
+		switch {
+		case tt.err && err == nil:
+			t.Errorf("expected %q to be invalid, got %s", tt.raw, actual)
+		case !tt.err && err != nil:
+			t.Errorf("expected %q to be valid: %v", tt.raw, err)
+		case actual != tt.expected:
+			t.Errorf("expected %q to be parsed as %d, got %d", tt.raw, tt.expected, actual)
+		}
+	}
+}
+
+func TestLSNString(t *testing.T) {
+	for _, raw := range []string{"0/0", "0/16B3748", "16/B374D848"} {
+		lsn, err := dbrouter.ParseLSN(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if lsn.String() != raw {
+			t.Errorf("expected %q, got %q", raw, lsn.String())
+		}
+	}
+}
@@ -0,0 +1,239 @@
+// Package dbrouter sends reads to replica and everything else to primary database.
+package dbrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtx"
+)
+
+const (
+	// replica reports replayed location, primary configured as replica reports its current location
+	replayedQuery = `SELECT COALESCE(pg_last_wal_replay_lsn(), pg_current_wal_lsn())::text`
+	currentQuery  = `SELECT pg_current_wal_lsn()::text`
+)
+
+// Router is DBTX that sends sqlc queries listed as reads to replica while it's healthy.
+// Reads of sessions that wrote recently go to primary until replica replays their writes.
+type Router struct {
+	primary       dbtx.DBTX
+	replica       dbtx.DBTX
+	logger        *zap.Logger
+	reads         map[string]bool
+	checkInterval time.Duration
+
+	// replayed is replica location from last check, zero if replica is unhealthy
+	replayed uint64
+}
+
+type Opt func(*Router)
+
+func Logger(logger *zap.Logger) Opt {
+	return func(r *Router) {
+		r.logger = logger
+	}
+}
+
+// Reads sets names of sqlc queries that may be sent to replica.
+func Reads(names ...string) Opt {
+	return func(r *Router) {
+		for _, name := range names {
+			r.reads[name] = true
+		}
+	}
+}
+
+// CheckInterval sets how often replica health and replayed location are checked by Run.
+func CheckInterval(interval time.Duration) Opt {
+	return func(r *Router) {
+		r.checkInterval = interval
+	}
+}
+
+// New creates router, replica is considered unhealthy until first check.
+func New(primary, replica dbtx.DBTX, opts ...Opt) *Router {
+	r := &Router{
+		primary:       primary,
+		replica:       replica,
+		logger:        zap.NewNop(),
+		reads:         make(map[string]bool),
+		checkInterval: time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func (r *Router) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	markWrite(ctx)
+	return r.primary.Exec(ctx, query, args...)
+}
+
+func (r *Router) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	if !r.read(ctx, query) {
+		return r.primary.Query(ctx, query, args...)
+	}
+
+	rows, err := r.replica.Query(ctx, query, args...)
+	if r.fallback(err) {
+		return r.primary.Query(ctx, query, args...)
+	}
+
+	return rows, err
+}
+
+func (r *Router) QueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+	if !r.read(ctx, query) {
+		return r.primary.QueryRow(ctx, query, args...)
+	}
+
+	return &row{
+		router: r,
+		ctx:    ctx,
+		query:  query,
+		args:   args,
+	}
+}
+
+func (r *Router) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	markWrite(ctx)
+	return r.primary.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+// Begin starts transaction on primary.
+func (r *Router) Begin(ctx context.Context) (pgx.Tx, error) {
+	beginner, ok := r.primary.(interface {
+		Begin(ctx context.Context) (pgx.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("begin is not supported by %T", r.primary)
+	}
+
+	markWrite(ctx)
+
+	return beginner.Begin(ctx)
+}
+
+// CurrentLSN returns current write location of primary.
+func (r *Router) CurrentLSN(ctx context.Context) (LSN, error) {
+	return queryLSN(ctx, r.primary, currentQuery)
+}
+
+// Check updates replica health and replayed location.
+func (r *Router) Check(ctx context.Context) error {
+	lsn, err := queryLSN(ctx, r.replica, replayedQuery)
+	if err != nil {
+		if atomic.SwapUint64(&r.replayed, 0) != 0 {
+			r.logger.Warn("replica unhealthy, reads fall back to primary", zap.Error(err))
+		}
+
+		return err
+	}
+
+	if atomic.SwapUint64(&r.replayed, uint64(lsn)) == 0 {
+		r.logger.Info("replica healthy", zap.Stringer("lsn", lsn))
+	}
+
+	return nil
+}
+
+// Run checks replica every check interval until ctx is done.
+func (r *Router) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, r.checkInterval)
+		_ = r.Check(checkCtx)
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// read reports whether query can be sent to replica.
+// Queries not listed as reads are writes of the session, reads sent to primary due to replica lag are not.
+func (r *Router) read(ctx context.Context, query string) bool {
+	if !r.reads[dbtx.QueryName(query)] {
+		markWrite(ctx)
+		return false
+	}
+
+	replayed := atomic.LoadUint64(&r.replayed)
+	if replayed == 0 {
+		return false
+	}
+
+	s := sessionFromContext(ctx)
+	return s == nil || s.minLSN <= LSN(replayed)
+}
+
+// fallback marks replica unhealthy if query failed before reaching it.
+func (r *Router) fallback(err error) bool {
+	if !unreachable(err) {
+		return false
+	}
+
+	if atomic.SwapUint64(&r.replayed, 0) != 0 {
+		r.logger.Warn("replica unhealthy, reads fall back to primary", zap.Error(err))
+	}
+
+	return true
+}
+
+// unreachable reports whether err is failure to connect to database or of network rather than of query.
+// Connect errors of pgconn don't report SafeToRetry, they wrap network error instead.
+func unreachable(err error) bool {
+	// context errors satisfy net.Error, query canceled by caller doesn't mean replica is unhealthy
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || pgconn.SafeToRetry(err)
+}
+
+type row struct {
+	router *Router
+	ctx    context.Context
+	query  string
+	args   []interface{}
+}
+
+func (r *row) Scan(dest ...interface{}) error {
+	err := r.router.replica.QueryRow(r.ctx, r.query, r.args...).Scan(dest...)
+	if r.router.fallback(err) {
+		return r.router.primary.QueryRow(r.ctx, r.query, r.args...).Scan(dest...)
+	}
+
+	return err
+}
+
+func queryLSN(ctx context.Context, db dbtx.DBTX, query string) (LSN, error) {
+	var raw *string
+	if err := db.QueryRow(ctx, query).Scan(&raw); err != nil {
+		return 0, fmt.Errorf("failed to query lsn: %w", err)
+	}
+
+	if raw == nil {
+		return 0, errors.New("failed to query lsn: wal location is not available")
+	}
+
+	return ParseLSN(*raw)
+}
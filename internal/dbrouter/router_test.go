@@ -0,0 +1,336 @@
+package dbrouter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbrouter"
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtx"
+)
+
+const (
+	getQuery    = "-- name: Get :one\nSELECT * FROM todo WHERE id = $1"
+	listQuery   = "-- name: List :many\nSELECT * FROM todo"
+	createQuery = "-- name: Create :one\nINSERT INTO todo (id) VALUES ($1) RETURNING *"
+)
+
+func TestRouter(t *testing.T) {
+	ctx := context.Background()
+	errUnreachable := connectError(t)
+
+	tests := []struct {
+		name string
+		// replica location reported by check, empty if check fails
+		replayed string
+		// error of queries sent to replica after check
+		replicaErr error
+		call       func(router *dbrouter.Router) error
+		primary    []string
+		replica    []string
+		err        error
+		healthy    bool
+	}{
+		{
+			name:     "read from healthy replica",
+			replayed: "0/3000",
+			call: func(router *dbrouter.Router) error {
+				_, err := router.Query(ctx, listQuery)
+				return err
+			},
+			replica: []string{"List"},
+			healthy: true,
+		},
+		{
+			name:     "read row from healthy replica",
+			replayed: "0/3000",
+			call: func(router *dbrouter.Router) error {
+				var id string
+				return router.QueryRow(ctx, getQuery).Scan(&id)
+			},
+			replica: []string{"Get"},
+			healthy: true,
+		},
+		{
+			name: "read from primary while replica is unhealthy",
+			call: func(router *dbrouter.Router) error {
+				_, err := router.Query(ctx, listQuery)
+				return err
+			},
+			primary: []string{"List"},
+		},
+		{
+			name:     "query not listed as read",
+			replayed: "0/3000",
+			call: func(router *dbrouter.Router) error {
+				var id string
+				return router.QueryRow(ctx, createQuery).Scan(&id)
+			},
+			primary: []string{"Create"},
+			healthy: true,
+		},
+		{
+			name:     "exec",
+			replayed: "0/3000",
+			call: func(router *dbrouter.Router) error {
+				_, err := router.Exec(ctx, "-- name: Delete :exec\nDELETE FROM todo WHERE id = $1")
+				return err
+			},
+			primary: []string{"Delete"},
+			healthy: true,
+		},
+		{
+			name:       "query falls back to primary if replica is unreachable",
+			replayed:   "0/3000",
+			replicaErr: errUnreachable,
+			call: func(router *dbrouter.Router) error {
+				_, err := router.Query(ctx, listQuery)
+				return err
+			},
+			primary: []string{"List"},
+			replica: []string{"List"},
+		},
+		{
+			name:       "query row falls back to primary if replica is unreachable",
+			replayed:   "0/3000",
+			replicaErr: errUnreachable,
+			call: func(router *dbrouter.Router) error {
+				var id string
+				return router.QueryRow(ctx, getQuery).Scan(&id)
+			},
+			primary: []string{"Get"},
+			replica: []string{"Get"},
+		},
+		{
+			name:       "canceled query doesn't fall back to primary",
+			replayed:   "0/3000",
+			replicaErr: context.Canceled,
+			call: func(router *dbrouter.Router) error {
+				_, err := router.Query(ctx, listQuery)
+				return err
+			},
+			replica: []string{"List"},
+			err:     context.Canceled,
+			healthy: true,
+		},
+		{
+			name:       "query error from replica",
+			replayed:   "0/3000",
+			replicaErr: pgx.ErrNoRows,
+			call: func(router *dbrouter.Router) error {
+				var id string
+				return router.QueryRow(ctx, getQuery).Scan(&id)
+			},
+			replica: []string{"Get"},
+			err:     pgx.ErrNoRows,
+			healthy: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &fakeDB{lsn: "0/4000"}
+			replica := &fakeDB{lsn: tt.replayed}
+			if tt.replayed == "" {
+				replica.err = errUnreachable
+			}
+
+			router := dbrouter.New(primary, replica, dbrouter.Reads("Get", "List"))
+
+			if err := router.Check(ctx); (err == nil) != (tt.replayed != "") {
+				t.Fatalf("unexpected check result: %v", err)
+			}
+
+			replica.err = tt.replicaErr
+			replica.queries = nil
+
+			if err := tt.call(router); !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+
+			if diff := cmp.Diff(tt.primary, primary.queries); diff != "" {
+				t.Error("expected queries sent to primary:", diff)
+			}
+
+			if diff := cmp.Diff(tt.replica, replica.queries); diff != "" {
+				t.Error("expected queries sent to replica:", diff)
+			}
+
+			// unhealthy replica is not queried
+			replica.queries = nil
+			if _, err := router.Query(ctx, listQuery); err != nil && !errors.Is(err, tt.replicaErr) {
+				t.Fatal(err)
+			}
+
+			if healthy := len(replica.queries) != 0; healthy != tt.healthy {
+				t.Errorf("expected replica healthy %t, got %t", tt.healthy, healthy)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		lsn     string
+		call    func(ctx context.Context, router *dbrouter.Router) error
+		primary []string
+		replica []string
+		header  string
+	}{
+		{
+			name: "read without location",
+			call: func(ctx context.Context, router *dbrouter.Router) error {
+				_, err := router.Query(ctx, listQuery)
+				return err
+			},
+			replica: []string{"List"},
+		},
+		{
+			name: "read with location replayed by replica",
+			lsn:  "0/3000",
+			call: func(ctx context.Context, router *dbrouter.Router) error {
+				_, err := router.Query(ctx, listQuery)
+				return err
+			},
+			replica: []string{"List"},
+		},
+		{
+			name: "read with location ahead of replica",
+			lsn:  "0/3001",
+			call: func(ctx context.Context, router *dbrouter.Router) error {
+				_, err := router.Query(ctx, listQuery)
+				return err
+			},
+			primary: []string{"List"},
+		},
+		{
+			name: "write returns primary location",
+			call: func(ctx context.Context, router *dbrouter.Router) error {
+				var id string
+				return router.QueryRow(ctx, createQuery).Scan(&id)
+			},
+			primary: []string{"Create", "unnamed"},
+			header:  "0/4000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &fakeDB{lsn: "0/4000"}
+			replica := &fakeDB{lsn: "0/3000"}
+
+			router := dbrouter.New(primary, replica, dbrouter.Reads("Get", "List"))
+			if err := router.Check(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			replica.queries = nil
+
+			handler := router.Middleware(time.Second)(http.MethodGet, "/api/v1/todo", func(w http.ResponseWriter, req *http.Request) error {
+				if err := tt.call(req.Context(), router); err != nil {
+					return err
+				}
+
+				w.WriteHeader(http.StatusOK)
+
+				return nil
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/todo", nil)
+			if tt.lsn != "" {
+				req.Header.Set(dbrouter.Header, tt.lsn)
+			}
+
+			rec := httptest.NewRecorder()
+			if err := handler(rec, req); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.primary, primary.queries); diff != "" {
+				t.Error("expected queries sent to primary:", diff)
+			}
+
+			if diff := cmp.Diff(tt.replica, replica.queries); diff != "" {
+				t.Error("expected queries sent to replica:", diff)
+			}
+
+			if header := rec.Header().Get(dbrouter.Header); header != tt.header {
+				t.Errorf("expected location header %q, got %q", tt.header, header)
+			}
+		})
+	}
+}
+
+// connectError returns error of connecting to closed port the way pool reports unreachable database.
+func connectError(t *testing.T) error {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := listener.Addr().(*net.TCPAddr)
+	listener.Close()
+
+	_, err = pgconn.Connect(context.Background(), fmt.Sprintf("postgres://todo@127.0.0.1:%d/todo?connect_timeout=5", addr.Port))
+	if err == nil {
+		t.Fatal("expected connect to closed port to fail")
+	}
+
+	return err
+}
+
+// fakeDB records names of queries and reports lsn as wal location.
+type fakeDB struct {
+	lsn     string
+	err     error
+	queries []string
+}
+
+func (db *fakeDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	db.queries = append(db.queries, dbtx.QueryName(sql))
+	return pgconn.CommandTag("DELETE 1"), db.err
+}
+
+func (db *fakeDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	db.queries = append(db.queries, dbtx.QueryName(sql))
+	return nil, db.err
+}
+
+func (db *fakeDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	db.queries = append(db.queries, dbtx.QueryName(sql))
+	return fakeRow{lsn: db.lsn, err: db.err}
+}
+
+func (db *fakeDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	db.queries = append(db.queries, tableName.Sanitize())
+	return 0, db.err
+}
+
+type fakeRow struct {
+	lsn string
+	err error
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if raw, ok := dest[0].(**string); ok {
+		*raw = &r.lsn
+	}
+
+	return nil
+}
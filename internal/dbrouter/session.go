@@ -0,0 +1,135 @@
+package dbrouter
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+)
+
+const (
+	// Header carries primary location after write, clients send it back to read their writes.
+	Header = "X-Todo-LSN"
+	// Cookie carries the same location as Header for clients that don't handle headers, it expires after window.
+	Cookie = "todo_lsn"
+)
+
+type contextKey struct{}
+
+type session struct {
+	minLSN LSN
+	wrote  int32
+}
+
+func sessionFromContext(ctx context.Context) *session {
+	s, _ := ctx.Value(contextKey{}).(*session)
+	return s
+}
+
+func markWrite(ctx context.Context) {
+	if s := sessionFromContext(ctx); s != nil {
+		atomic.StoreInt32(&s.wrote, 1)
+	}
+}
+
+// Middleware guarantees read-your-writes within window after a write.
+// Requests that wrote to primary receive its location in Header and Cookie before the response is written.
+// Reads of requests carrying location go to primary until replica replays it.
+func (r *Router) Middleware(window time.Duration) routes.Middleware {
+	maxAge := int(math.Ceil(window.Seconds()))
+
+	return func(method, path string, next routes.Handler) routes.Handler {
+		return func(w http.ResponseWriter, req *http.Request) error {
+			s := &session{
+				minLSN: requestLSN(req),
+			}
+
+			ctx := context.WithValue(req.Context(), contextKey{}, s)
+
+			sw := &sessionWriter{
+				ResponseWriter: w,
+				before: func() {
+					if atomic.LoadInt32(&s.wrote) == 0 {
+						return
+					}
+
+					lsn, err := r.CurrentLSN(ctx)
+					if err != nil {
+						logging.Logger(ctx, r.logger).Warn("failed to get primary lsn", zap.Error(err))
+						return
+					}
+
+					w.Header().Set(Header, lsn.String())
+					http.SetCookie(w, &http.Cookie{
+						Name:     Cookie,
+						Value:    lsn.String(),
+						Path:     "/",
+						MaxAge:   maxAge,
+						HttpOnly: true,
+						SameSite: http.SameSiteLaxMode,
+					})
+				},
+			}
+
+			return next(sw, req.WithContext(ctx))
+		}
+	}
+}
+
+// requestLSN returns the highest valid location sent in header or cookie, invalid locations are ignored.
+func requestLSN(req *http.Request) LSN {
+	var lsn LSN
+
+	if raw := req.Header.Get(Header); raw != "" {
+		if l, err := ParseLSN(raw); err == nil && l > lsn {
+			lsn = l
+		}
+	}
+
+	if cookie, err := req.Cookie(Cookie); err == nil {
+		if l, err := ParseLSN(cookie.Value); err == nil && l > lsn {
+			lsn = l
+		}
+	}
+
+	return lsn
+}
+
+// sessionWriter calls before once just before the response is started.
+type sessionWriter struct {
+	http.ResponseWriter
+	before  func()
+	started bool
+}
+
+func (sw *sessionWriter) WriteHeader(status int) {
+	sw.start()
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *sessionWriter) Write(b []byte) (int, error) {
+	sw.start()
+	return sw.ResponseWriter.Write(b)
+}
+
+func (sw *sessionWriter) Flush() {
+	if f, ok := sw.ResponseWriter.(http.Flusher); ok {
+		sw.start()
+		f.Flush()
+	}
+}
+
+func (sw *sessionWriter) start() {
+	if sw.started {
+		return
+	}
+
+	sw.started = true
+	sw.before()
+}
@@ -0,0 +1,78 @@
+package dbrouter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestLSN(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		cookie   string
+		expected LSN
+	}{
+		{name: "none", expected: 0},
+		{name: "header", header: "0/3000", expected: 0x3000},
+		{name: "cookie", cookie: "0/3000", expected: 0x3000},
+		{name: "header ahead of cookie", header: "1/0", cookie: "0/3000", expected: 0x100000000},
+		{name: "cookie ahead of header", header: "0/3000", cookie: "0/4000", expected: 0x4000},
+		{name: "invalid header", header: "invalid", cookie: "0/3000", expected: 0x3000},
+		{name: "invalid cookie", header: "0/3000", cookie: "invalid", expected: 0x3000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(Header, tt.header)
+			}
+
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: Cookie, Value: tt.cookie})
+			}
+
+			if actual := requestLSN(req); actual != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSessionWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(w http.ResponseWriter)
+	}{
+		{name: "write header", write: func(w http.ResponseWriter) { w.WriteHeader(http.StatusNoContent) }},
+		{name: "write", write: func(w http.ResponseWriter) { _, _ = w.Write([]byte("ok")) }},
+		{name: "flush", write: func(w http.ResponseWriter) { w.(http.Flusher).Flush() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			calls := 0
+			sw := &sessionWriter{
+				ResponseWriter: rec,
+				before: func() {
+					calls++
+					rec.Header().Set(Header, "0/3000")
+				},
+			}
+
+			tt.write(sw)
+			_, _ = sw.Write([]byte("done"))
+
+			if calls != 1 {
+				t.Errorf("expected before to be called once, got %d", calls)
+			}
+
+			if actual := rec.Result().Header.Get(Header); actual != "0/3000" {
+				t.Errorf("expected header set before response is started, got %q", actual)
+			}
+		})
+	}
+}
@@ -9,11 +9,11 @@ import (
 	"database/sql"
 	"fmt"
 	"math/big"
+	"os"
 	"testing"
 
 	"github.com/cenkalti/backoff/v3"
 	"github.com/ory/dockertest/v3"
-	"github.com/pressly/goose/v3"
 
 	"github.com/shaxbee/todo-app-skaffold/internal/dbutil"
 )
@@ -26,8 +26,21 @@ func SetupPostgres(t testing.TB, opts ...Opt) *sql.DB {
 		opt(&c)
 	}
 
+	return openDB(t, SetupPostgresDSN(t, opts...), "", c.Backoff())
+}
+
+// SetupPostgresDSN starts database and runs migrations, it returns DSN for callers that open the database on their own.
+func SetupPostgresDSN(t testing.TB, opts ...Opt) string {
+	t.Helper()
+
+	c := defaultConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+
 	if dsn := c.DSN(); dsn != "" {
-		return openDB(t, dsn, c.migrations, c.Backoff())
+		migrate(t, dsn, c.migrations, c.Backoff())
+		return dsn
 	}
 
 	pool, err := dockertest.NewPool("")
@@ -64,7 +77,19 @@ func SetupPostgres(t testing.TB, opts ...Opt) *sql.DB {
 	t.Logf("dbtest: started container %q", name)
 
 	dsn := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable", resource.GetBoundIP("5432/tcp"), resource.GetPort("5432/tcp"), c.user, c.database)
-	return openDB(t, dsn, c.migrations, c.Backoff())
+	migrate(t, dsn, c.migrations, c.Backoff())
+
+	return dsn
+}
+
+// migrate waits for database to become reachable and runs migrations.
+func migrate(t testing.TB, dsn, migrations string, bo backoff.BackOff) {
+	t.Helper()
+
+	db := openDB(t, dsn, migrations, bo)
+	if err := db.Close(); err != nil {
+		t.Fatalf("dbtest: close database: %v", err)
+	}
 }
 
 func openDB(t testing.TB, dsn, migrations string, bo backoff.BackOff) *sql.DB {
@@ -80,7 +105,7 @@ func openDB(t testing.TB, dsn, migrations string, bo backoff.BackOff) *sql.DB {
 	if migrations != "" {
 		t.Logf("dbtest: running migrations from %q", migrations)
 
-		if err := goose.Up(db, migrations); err != nil {
+		if err := dbutil.Migrate(db, "postgres", os.DirFS(migrations)); err != nil {
 			t.Fatalf("dbtest: migrate: %v", err)
 		}
 	}
@@ -0,0 +1,242 @@
+// Package dbtx decorates sqlc DBTX of pgx and database/sql with interceptors for instrumentation.
+package dbtx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	OpExec     = "exec"
+	OpQuery    = "query"
+	OpQueryRow = "query_row"
+	OpCopyFrom = "copy_from"
+
+	unnamedQuery = "unnamed"
+)
+
+// DBTX is implemented by *pgxpool.Pool, *pgx.Conn, pgx.Tx and sqlc generated DBTX.
+type DBTX interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// Query describes intercepted call.
+type Query struct {
+	// Name of sqlc query, such as GetTodo, or copied table.
+	Name string
+	// Op is one of OpExec, OpQuery, OpQueryRow and OpCopyFrom.
+	Op  string
+	SQL string
+}
+
+// Result of intercepted call.
+type Result struct {
+	// Rows affected by exec or returned by query_row, -1 if not known upfront such as for streamed query rows.
+	Rows int64
+}
+
+// Next calls next interceptor or the underlying DBTX.
+type Next func(ctx context.Context) (Result, error)
+
+// Interceptor is called around each query.
+// Errors returned by interceptor are returned to the caller.
+type Interceptor func(ctx context.Context, query Query, next Next) (Result, error)
+
+// DB is DBTX calling interceptors around each query.
+type DB struct {
+	db           DBTX
+	interceptors []Interceptor
+}
+
+// Wrap db with interceptors, first interceptor is the outermost one.
+func Wrap(db DBTX, interceptors ...Interceptor) *DB {
+	return &DB{
+		db:           db,
+		interceptors: interceptors,
+	}
+}
+
+func (db *DB) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	var tag pgconn.CommandTag
+
+	err := db.intercept(ctx, OpExec, query, func(ctx context.Context) (Result, error) {
+		var err error
+		tag, err = db.db.Exec(ctx, query, args...)
+		if err != nil {
+			return Result{Rows: -1}, err
+		}
+
+		return Result{Rows: tag.RowsAffected()}, nil
+	})
+
+	return tag, err
+}
+
+func (db *DB) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	var rows pgx.Rows
+
+	err := db.intercept(ctx, OpQuery, query, func(ctx context.Context) (Result, error) {
+		var err error
+		rows, err = db.db.Query(ctx, query, args...)
+
+		return Result{Rows: -1}, err
+	})
+
+	return rows, err
+}
+
+// QueryRow is executed when the row is scanned as pgx reports errors of the row only on Scan.
+func (db *DB) QueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+	return &row{
+		db:    db,
+		ctx:   ctx,
+		query: query,
+		args:  args,
+	}
+}
+
+func (db *DB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	var n int64
+
+	query := Query{
+		Name: strings.Join(tableName, "."),
+		Op:   OpCopyFrom,
+		SQL:  fmt.Sprintf("COPY %s (%s) FROM STDIN", tableName.Sanitize(), strings.Join(columnNames, ", ")),
+	}
+
+	err := db.call(ctx, query, func(ctx context.Context) (Result, error) {
+		var err error
+		n, err = db.db.CopyFrom(ctx, tableName, columnNames, rowSrc)
+		if err != nil {
+			return Result{Rows: -1}, err
+		}
+
+		return Result{Rows: n}, nil
+	})
+
+	return n, err
+}
+
+// Begin starts transaction on the underlying DBTX, queries executed in the transaction are intercepted.
+func (db *DB) Begin(ctx context.Context) (pgx.Tx, error) {
+	beginner, ok := db.db.(interface {
+		Begin(ctx context.Context) (pgx.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("begin is not supported by %T", db.db)
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrappedTx{
+		Tx: tx,
+		db: Wrap(tx, db.interceptors...),
+	}, nil
+}
+
+func (db *DB) intercept(ctx context.Context, op, sql string, call Next) error {
+	return db.call(ctx, Query{
+		Name: QueryName(sql),
+		Op:   op,
+		SQL:  sql,
+	}, call)
+}
+
+func (db *DB) call(ctx context.Context, query Query, call Next) error {
+	return intercept(ctx, db.interceptors, query, call)
+}
+
+// intercept calls interceptors around call, first interceptor is the outermost one.
+func intercept(ctx context.Context, interceptors []Interceptor, query Query, call Next) error {
+	next := call
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := interceptors[i], next
+		next = func(ctx context.Context) (Result, error) {
+			return interceptor(ctx, query, inner)
+		}
+	}
+
+	_, err := next(ctx)
+
+	return err
+}
+
+// wrappedTx intercepts queries executed in transaction.
+type wrappedTx struct {
+	pgx.Tx
+	db *DB
+}
+
+func (tx *wrappedTx) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	return tx.db.Exec(ctx, query, args...)
+}
+
+func (tx *wrappedTx) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	return tx.db.Query(ctx, query, args...)
+}
+
+func (tx *wrappedTx) QueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+	return tx.db.QueryRow(ctx, query, args...)
+}
+
+func (tx *wrappedTx) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return tx.db.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+// Begin starts savepoint, queries executed in it are intercepted as well.
+func (tx *wrappedTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	return tx.db.Begin(ctx)
+}
+
+type row struct {
+	db    *DB
+	ctx   context.Context
+	query string
+	args  []interface{}
+}
+
+func (r *row) Scan(dest ...interface{}) error {
+	return r.db.intercept(r.ctx, OpQueryRow, r.query, func(ctx context.Context) (Result, error) {
+		err := r.db.db.QueryRow(ctx, r.query, r.args...).Scan(dest...)
+
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return Result{Rows: 0}, err
+		case err != nil:
+			return Result{Rows: -1}, err
+		default:
+			return Result{Rows: 1}, nil
+		}
+	})
+}
+
+// QueryName extracts name from sqlc query comment such as "-- name: GetTodo :one".
+func QueryName(query string) string {
+	const prefix = "-- name: "
+
+	if !strings.HasPrefix(query, prefix) {
+		return unnamedQuery
+	}
+
+	line := query[len(prefix):]
+	if i := strings.IndexAny(line, " \n"); i != -1 {
+		line = line[:i]
+	}
+
+	if line == "" {
+		return unnamedQuery
+	}
+
+	return line
+}
@@ -0,0 +1,192 @@
+package dbtx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtx"
+)
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{query: "-- name: GetTodo :one\nSELECT * FROM todo WHERE id = $1", expected: "GetTodo"},
+		{query: "-- name: DeleteAllTodos\nDELETE FROM todo", expected: "DeleteAllTodos"},
+		{query: "-- name: ListTodos", expected: "ListTodos"},
+		{query: "-- name: \nSELECT 1", expected: "unnamed"},
+		{query: "SELECT 1", expected: "unnamed"},
+		{query: "", expected: "unnamed"},
+	}
+
+	for _, tt := range tests {
+		if actual := dbtx.QueryName(tt.query); actual != tt.expected {
+			t.Errorf("expected name %q of query %q, got %q", tt.expected, tt.query, actual)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	type call struct {
+		Interceptor string
+		Query       dbtx.Query
+		Rows        int64
+		Err         error
+	}
+
+	var calls []call
+
+	record := func(name string) dbtx.Interceptor {
+		return func(ctx context.Context, query dbtx.Query, next dbtx.Next) (dbtx.Result, error) {
+			res, err := next(ctx)
+			calls = append(calls, call{Interceptor: name, Query: query, Rows: res.Rows, Err: err})
+
+			return res, err
+		}
+	}
+
+	fake := &fakeDB{}
+	db := dbtx.Wrap(fake, record("outer"), record("inner"))
+
+	tests := []struct {
+		name     string
+		row      error
+		call     func() error
+		expected []call
+	}{
+		{
+			name: "exec",
+			call: func() error {
+				_, err := db.Exec(ctx, "-- name: DeleteAllTodos :execrows\nDELETE FROM todo")
+				return err
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "DeleteAllTodos", Op: dbtx.OpExec, SQL: "-- name: DeleteAllTodos :execrows\nDELETE FROM todo"}, Rows: 3},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "DeleteAllTodos", Op: dbtx.OpExec, SQL: "-- name: DeleteAllTodos :execrows\nDELETE FROM todo"}, Rows: 3},
+			},
+		},
+		{
+			name: "query",
+			call: func() error {
+				_, err := db.Query(ctx, "SELECT 1")
+				return err
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "unnamed", Op: dbtx.OpQuery, SQL: "SELECT 1"}, Rows: -1},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "unnamed", Op: dbtx.OpQuery, SQL: "SELECT 1"}, Rows: -1},
+			},
+		},
+		{
+			name: "query row",
+			call: func() error {
+				var id int
+				return db.QueryRow(ctx, "-- name: GetTodo :one\nSELECT 1").Scan(&id)
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "GetTodo", Op: dbtx.OpQueryRow, SQL: "-- name: GetTodo :one\nSELECT 1"}, Rows: 1},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "GetTodo", Op: dbtx.OpQueryRow, SQL: "-- name: GetTodo :one\nSELECT 1"}, Rows: 1},
+			},
+		},
+		{
+			name: "query row without rows",
+			row:  pgx.ErrNoRows,
+			call: func() error {
+				var id int
+				if err := db.QueryRow(ctx, "SELECT 1").Scan(&id); !errors.Is(err, pgx.ErrNoRows) {
+					return err
+				}
+
+				return nil
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "unnamed", Op: dbtx.OpQueryRow, SQL: "SELECT 1"}, Rows: 0, Err: pgx.ErrNoRows},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "unnamed", Op: dbtx.OpQueryRow, SQL: "SELECT 1"}, Rows: 0, Err: pgx.ErrNoRows},
+			},
+		},
+		{
+			name: "failed query row",
+			row:  errFailed,
+			call: func() error {
+				var id int
+				if err := db.QueryRow(ctx, "SELECT 1").Scan(&id); !errors.Is(err, errFailed) {
+					return err
+				}
+
+				return nil
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "unnamed", Op: dbtx.OpQueryRow, SQL: "SELECT 1"}, Rows: -1, Err: errFailed},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "unnamed", Op: dbtx.OpQueryRow, SQL: "SELECT 1"}, Rows: -1, Err: errFailed},
+			},
+		},
+		{
+			name: "copy from",
+			call: func() error {
+				_, err := db.CopyFrom(ctx, pgx.Identifier{"public", "todo"}, []string{"id", "title"}, pgx.CopyFromRows(nil))
+				return err
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "public.todo", Op: dbtx.OpCopyFrom, SQL: `COPY "public"."todo" (id, title) FROM STDIN`}, Rows: 2},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "public.todo", Op: dbtx.OpCopyFrom, SQL: `COPY "public"."todo" (id, title) FROM STDIN`}, Rows: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			fake.row = tt.row
+
+			if err := tt.call(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expected, calls, cmp.Comparer(errors.Is)); diff != "" {
+				t.Error("expected interceptors called from innermost:", diff)
+			}
+		})
+	}
+
+	t.Run("begin", func(t *testing.T) {
+		if _, err := db.Begin(ctx); err == nil {
+			t.Error("expected begin to fail for DBTX without transactions")
+		}
+	})
+}
+
+type fakeDB struct {
+	row error
+}
+
+func (db *fakeDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag("DELETE 3"), nil
+}
+
+func (db *fakeDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, nil
+}
+
+func (db *fakeDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return fakeRow{err: db.row}
+}
+
+func (db *fakeDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return 2, nil
+}
+
+type fakeRow struct {
+	err error
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	return r.err
+}
@@ -0,0 +1,52 @@
+package dbtx
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+)
+
+// Log logs each query with its duration and row count.
+// Queries are logged at debug level and queries slower than slowThreshold at warn level.
+// Failed queries are logged at debug level as errors are returned to the handler which logs them once.
+// Zero slowThreshold disables slow query logging.
+func Log(logger *zap.Logger, slowThreshold time.Duration) Interceptor {
+	return func(ctx context.Context, query Query, next Next) (Result, error) {
+		start := time.Now()
+		res, err := next(ctx)
+		duration := time.Since(start)
+
+		fields := []zap.Field{
+			zap.String("query", query.Name),
+			zap.String("op", query.Op),
+			zap.Duration("duration", duration),
+		}
+
+		if res.Rows >= 0 {
+			fields = append(fields, zap.Int64("rows", res.Rows))
+		}
+
+		logger := logging.Logger(ctx, logger)
+
+		failed := err != nil && !errors.Is(err, pgx.ErrNoRows)
+		if failed {
+			fields = append(fields, zap.Error(err))
+		}
+
+		switch {
+		case slowThreshold > 0 && duration >= slowThreshold:
+			logger.Warn("slow query", append(fields, zap.Duration("threshold", slowThreshold))...)
+		case failed:
+			logger.Debug("query failed", fields...)
+		default:
+			logger.Debug("query", fields...)
+		}
+
+		return res, err
+	}
+}
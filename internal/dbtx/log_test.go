@@ -0,0 +1,128 @@
+package dbtx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtx"
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+)
+
+func TestLog(t *testing.T) {
+	type entry struct {
+		Level   zapcore.Level
+		Message string
+		Fields  map[string]interface{}
+	}
+
+	query := dbtx.Query{Name: "GetTodo", Op: dbtx.OpQueryRow}
+
+	tests := []struct {
+		name     string
+		rows     int64
+		err      error
+		delay    time.Duration
+		expected entry
+	}{
+		{
+			name: "query",
+			rows: 1,
+			expected: entry{
+				Level:   zapcore.DebugLevel,
+				Message: "query",
+				Fields:  map[string]interface{}{"query": "GetTodo", "op": "query_row", "rows": int64(1)},
+			},
+		},
+		{
+			name: "unknown rows",
+			rows: -1,
+			expected: entry{
+				Level:   zapcore.DebugLevel,
+				Message: "query",
+				Fields:  map[string]interface{}{"query": "GetTodo", "op": "query_row"},
+			},
+		},
+		{
+			name: "no rows",
+			err:  pgx.ErrNoRows,
+			expected: entry{
+				Level:   zapcore.DebugLevel,
+				Message: "query",
+				Fields:  map[string]interface{}{"query": "GetTodo", "op": "query_row", "rows": int64(0)},
+			},
+		},
+		{
+			name: "failed",
+			rows: -1,
+			err:  errors.New("connection reset"),
+			expected: entry{
+				Level:   zapcore.DebugLevel,
+				Message: "query failed",
+				Fields:  map[string]interface{}{"query": "GetTodo", "op": "query_row", "error": "connection reset"},
+			},
+		},
+		{
+			name:  "slow",
+			rows:  1,
+			delay: 10 * time.Millisecond,
+			expected: entry{
+				Level:   zapcore.WarnLevel,
+				Message: "slow query",
+				Fields:  map[string]interface{}{"query": "GetTodo", "op": "query_row", "rows": int64(1), "threshold": 5 * time.Millisecond},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			interceptor := dbtx.Log(zap.New(core), 5*time.Millisecond)
+
+			res, err := interceptor(context.Background(), query, func(ctx context.Context) (dbtx.Result, error) {
+				time.Sleep(tt.delay)
+				return dbtx.Result{Rows: tt.rows}, tt.err
+			})
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+
+			if res.Rows != tt.rows {
+				t.Errorf("expected %d rows, got %d", tt.rows, res.Rows)
+			}
+
+			var actual []entry
+			for _, e := range logs.All() {
+				fields := e.ContextMap()
+				// duration is not deterministic
+				delete(fields, "duration")
+
+				actual = append(actual, entry{Level: e.Level, Message: e.Message, Fields: fields})
+			}
+
+			if diff := cmp.Diff([]entry{tt.expected}, actual); diff != "" {
+				t.Error("expected single log entry:", diff)
+			}
+		})
+	}
+
+	t.Run("context fields", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		ctx := logging.WithFields(context.Background(), zap.String("request_id", "abc"))
+
+		_, _ = dbtx.Log(zap.New(core), 0)(ctx, query, func(ctx context.Context) (dbtx.Result, error) {
+			return dbtx.Result{Rows: 1}, nil
+		})
+
+		if entries := logs.FilterField(zap.String("request_id", "abc")).Len(); entries != 1 {
+			t.Errorf("expected entry with request id, got %d", entries)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+)
+
+// SQLDBTX is implemented by *sql.DB, *sql.Tx and sqlc DBTX generated for database/sql.
+type SQLDBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// SQLDB is SQLDBTX calling interceptors around each query.
+type SQLDB struct {
+	db           SQLDBTX
+	interceptors []Interceptor
+}
+
+// WrapSQL wraps database/sql db with interceptors, first interceptor is the outermost one.
+// Transactions are not started by SQLDB, *sql.Tx is wrapped separately to intercept its queries.
+func WrapSQL(db SQLDBTX, interceptors ...Interceptor) *SQLDB {
+	return &SQLDB{
+		db:           db,
+		interceptors: interceptors,
+	}
+}
+
+func (db *SQLDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var res sql.Result
+
+	err := db.intercept(ctx, OpExec, query, func(ctx context.Context) (Result, error) {
+		var err error
+		res, err = db.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return Result{Rows: -1}, err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return Result{Rows: -1}, nil
+		}
+
+		return Result{Rows: rows}, nil
+	})
+
+	return res, err
+}
+
+// PrepareContext is not intercepted, executions of the statement are not intercepted either.
+func (db *SQLDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return db.db.PrepareContext(ctx, query)
+}
+
+func (db *SQLDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+
+	err := db.intercept(ctx, OpQuery, query, func(ctx context.Context) (Result, error) {
+		var err error
+		rows, err = db.db.QueryContext(ctx, query, args...)
+
+		return Result{Rows: -1}, err
+	})
+
+	return rows, err
+}
+
+// QueryRowContext is intercepted when query is executed, missing row is reported only when the row is scanned.
+// Errors of interceptors are not returned as *sql.Row can't carry them, interceptors should call next.
+func (db *SQLDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	var row *sql.Row
+
+	_ = db.intercept(ctx, OpQueryRow, query, func(ctx context.Context) (Result, error) {
+		row = db.db.QueryRowContext(ctx, query, args...)
+		return Result{Rows: -1}, row.Err()
+	})
+
+	return row
+}
+
+func (db *SQLDB) intercept(ctx context.Context, op, sql string, call Next) error {
+	return intercept(ctx, db.interceptors, Query{
+		Name: QueryName(sql),
+		Op:   op,
+		SQL:  sql,
+	}, call)
+}
@@ -0,0 +1,146 @@
+package dbtx_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtx"
+	"github.com/shaxbee/todo-app-skaffold/internal/dbutil"
+)
+
+func TestWrapSQL(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := sql.Open(dbutil.SQLiteDriver, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// each connection opens separate in-memory database
+	conn.SetMaxOpenConns(1)
+
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE todo (id INTEGER PRIMARY KEY); INSERT INTO todo (id) VALUES (1), (2), (3)"); err != nil {
+		t.Fatal(err)
+	}
+
+	type call struct {
+		Interceptor string
+		Query       dbtx.Query
+		Rows        int64
+		Err         bool
+	}
+
+	var calls []call
+
+	record := func(name string) dbtx.Interceptor {
+		return func(ctx context.Context, query dbtx.Query, next dbtx.Next) (dbtx.Result, error) {
+			res, err := next(ctx)
+			calls = append(calls, call{Interceptor: name, Query: query, Rows: res.Rows, Err: err != nil})
+
+			return res, err
+		}
+	}
+
+	const (
+		deleteQuery = "-- name: DeleteAllTodos :execrows\nDELETE FROM todo"
+		getQuery    = "-- name: GetTodo :one\nSELECT id FROM todo WHERE id = ?"
+	)
+
+	tests := []struct {
+		name     string
+		call     func(db *dbtx.SQLDB) error
+		expected []call
+	}{
+		{
+			name: "exec",
+			call: func(db *dbtx.SQLDB) error {
+				_, err := db.ExecContext(ctx, deleteQuery)
+				return err
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "DeleteAllTodos", Op: dbtx.OpExec, SQL: deleteQuery}, Rows: 3},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "DeleteAllTodos", Op: dbtx.OpExec, SQL: deleteQuery}, Rows: 3},
+			},
+		},
+		{
+			name: "query",
+			call: func(db *dbtx.SQLDB) error {
+				rows, err := db.QueryContext(ctx, "SELECT id FROM todo")
+				if err != nil {
+					return err
+				}
+
+				return rows.Close()
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "unnamed", Op: dbtx.OpQuery, SQL: "SELECT id FROM todo"}, Rows: -1},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "unnamed", Op: dbtx.OpQuery, SQL: "SELECT id FROM todo"}, Rows: -1},
+			},
+		},
+		{
+			name: "query row",
+			call: func(db *dbtx.SQLDB) error {
+				var id int
+				return db.QueryRowContext(ctx, getQuery, 1).Scan(&id)
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "GetTodo", Op: dbtx.OpQueryRow, SQL: getQuery}, Rows: -1},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "GetTodo", Op: dbtx.OpQueryRow, SQL: getQuery}, Rows: -1},
+			},
+		},
+		{
+			name: "query row without rows",
+			call: func(db *dbtx.SQLDB) error {
+				var id int
+				if err := db.QueryRowContext(ctx, getQuery, 4).Scan(&id); !errors.Is(err, sql.ErrNoRows) {
+					return err
+				}
+
+				return nil
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "GetTodo", Op: dbtx.OpQueryRow, SQL: getQuery}, Rows: -1},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "GetTodo", Op: dbtx.OpQueryRow, SQL: getQuery}, Rows: -1},
+			},
+		},
+		{
+			name: "failed query",
+			call: func(db *dbtx.SQLDB) error {
+				if _, err := db.QueryContext(ctx, "SELECT id FROM missing"); err == nil {
+					return errors.New("expected query of missing table to fail")
+				}
+
+				return nil
+			},
+			expected: []call{
+				{Interceptor: "inner", Query: dbtx.Query{Name: "unnamed", Op: dbtx.OpQuery, SQL: "SELECT id FROM missing"}, Rows: -1, Err: true},
+				{Interceptor: "outer", Query: dbtx.Query{Name: "unnamed", Op: dbtx.OpQuery, SQL: "SELECT id FROM missing"}, Rows: -1, Err: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := conn.BeginTx(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tx.Rollback() //nolint:errcheck
+
+			calls = nil
+
+			if err := tt.call(dbtx.WrapSQL(tx, record("outer"), record("inner"))); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expected, calls); diff != "" {
+				t.Error("expected interceptors called from innermost:", diff)
+			}
+		})
+	}
+}
@@ -32,10 +32,34 @@ func MaxOpenConns(openConns int) Opt {
 	}
 }
 
+// ConnMaxLifetime closes connections older than lifetime, zero keeps connections open forever.
+func ConnMaxLifetime(lifetime time.Duration) Opt {
+	return func(c *config) {
+		c.ConnMaxLifetime = lifetime
+	}
+}
+
+// ConnMaxIdleTime closes connections idle for longer than idleTime, zero keeps idle connections open.
+func ConnMaxIdleTime(idleTime time.Duration) Opt {
+	return func(c *config) {
+		c.ConnMaxIdleTime = idleTime
+	}
+}
+
+// Lazy skips waiting for database to become reachable, connections are established on first use.
+func Lazy(lazy bool) Opt {
+	return func(c *config) {
+		c.lazy = lazy
+	}
+}
+
 type config struct {
-	backoff      backoff.BackOff
-	MaxIdleConns int
-	MaxOpenConns int
+	backoff         backoff.BackOff
+	lazy            bool
+	MaxIdleConns    int
+	MaxOpenConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
 }
 
 func (c config) Backoff(ctx context.Context) backoff.BackOff {
@@ -0,0 +1,69 @@
+package dbutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+)
+
+// LatestMigration returns the highest goose migration version in fsys.
+func LatestMigration(fsys fs.FS) (int64, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var latest int64
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		version, err := goose.NumericComponent(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		if version > latest {
+			latest = version
+		}
+	}
+
+	return latest, nil
+}
+
+// MigrationVersion returns the goose migration version applied to the database.
+// Unlike goose.GetDBVersion it does not create the version table, so it's safe to call from health checks.
+func MigrationVersion(ctx context.Context, db *sql.DB) (int64, error) {
+	// last row of each version tells whether it was applied or rolled back
+	query := fmt.Sprintf(`SELECT COALESCE(MAX(version_id), 0) FROM (
+	SELECT DISTINCT ON (version_id) version_id, is_applied FROM %s ORDER BY version_id, id DESC
+) AS versions WHERE is_applied`, goose.TableName())
+
+	var version int64
+	if err := db.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to get migration version: %w", err)
+	}
+
+	return version, nil
+}
+
+// Migrate applies migrations from fsys using goose dialect.
+func Migrate(db *sql.DB, dialect string, fsys fs.FS) error {
+	goose.SetBaseFS(fsys)
+	defer goose.SetBaseFS(nil)
+
+	if err := goose.SetDialect(dialect); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	if err := goose.Up(db, "."); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return nil
+}
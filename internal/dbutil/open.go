@@ -3,10 +3,13 @@ package dbutil
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/cenkalti/backoff/v3"
 )
 
+// Open opens database using driver and waits until it's reachable unless it's lazy.
+// Ping is retried with backoff until it succeeds, backoff gives up or ctx is done.
 func Open(ctx context.Context, driver, dsn string, opts ...Opt) (*sql.DB, error) {
 	c := defaultConfig
 
@@ -14,23 +17,32 @@ func Open(ctx context.Context, driver, dsn string, opts ...Opt) (*sql.DB, error)
 		opt(&c)
 	}
 
-	var db *sql.DB
-
-	err := backoff.Retry(func() error {
-		var err error
-		db, err = sql.Open("pgx", dsn)
-		if err != nil {
-			return err
-		}
-
-		return db.Ping()
-	}, c.Backoff(ctx))
+	db, err := sql.Open(driver, dsn)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	db.SetMaxIdleConns(c.MaxIdleConns)
 	db.SetMaxOpenConns(c.MaxOpenConns)
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+
+	if c.lazy {
+		return db, nil
+	}
+
+	err = backoff.Retry(func() error {
+		return db.PingContext(ctx)
+	}, c.Backoff(ctx))
+	if err != nil {
+		db.Close()
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("failed to connect to database: %w: %v", ctxErr, err)
+		}
+
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
 
 	return db, nil
 }
@@ -0,0 +1,95 @@
+package dbutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/cenkalti/backoff/v3"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/jackc/pgx/v4/stdlib"
+)
+
+// OpenPool creates pgx connection pool and waits until database is reachable the same way as Open unless it's lazy.
+// MaxOpenConns is the maximum pool size and MaxIdleConns is the minimum pool size kept open by health check.
+func OpenPool(ctx context.Context, dsn string, opts ...Opt) (*pgxpool.Pool, error) {
+	c := defaultConfig
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	config, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database config: %w", err)
+	}
+
+	if c.MaxOpenConns > 0 {
+		config.MaxConns = int32(c.MaxOpenConns)
+	}
+
+	config.MinConns = int32(c.MaxIdleConns)
+	if config.MinConns > config.MaxConns {
+		config.MinConns = config.MaxConns
+	}
+
+	// pgxpool treats zero as expired immediately while database/sql treats it as unlimited
+	config.MaxConnLifetime = unlimited(c.ConnMaxLifetime)
+	config.MaxConnIdleTime = unlimited(c.ConnMaxIdleTime)
+
+	// connection is established by ping below so that it's retried
+	config.LazyConnect = true
+
+	pool, err := pgxpool.ConnectConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if c.lazy {
+		return pool, nil
+	}
+
+	err = backoff.Retry(func() error {
+		return pool.Ping(ctx)
+	}, c.Backoff(ctx))
+	if err != nil {
+		pool.Close()
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("failed to connect to database: %w: %v", ctxErr, err)
+		}
+
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return pool, nil
+}
+
+// SQL adapts pool to database/sql for libraries such as goose.
+// Connections are opened with the pool connection config but they are not shared with the pool.
+func SQL(pool *pgxpool.Pool, opts ...Opt) *sql.DB {
+	c := defaultConfig
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	db := stdlib.OpenDB(*pool.Config().ConnConfig)
+
+	db.SetMaxIdleConns(c.MaxIdleConns)
+	db.SetMaxOpenConns(c.MaxOpenConns)
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+
+	return db
+}
+
+func unlimited(d time.Duration) time.Duration {
+	if d <= 0 {
+		return math.MaxInt64
+	}
+
+	return d
+}
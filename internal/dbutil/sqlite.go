@@ -0,0 +1,57 @@
+package dbutil
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3" // register sqlite3 driver
+)
+
+const (
+	// SQLiteDriver is the database/sql driver name of SQLite.
+	SQLiteDriver = "sqlite3"
+
+	sqliteScheme = "sqlite://"
+)
+
+// IsSQLite reports whether dsn has sqlite:// scheme.
+func IsSQLite(dsn string) bool {
+	return strings.HasPrefix(dsn, sqliteScheme)
+}
+
+// SQLiteDSN converts sqlite://path?params to sqlite3 driver DSN, sqlite:///path is an absolute path.
+// Unless set in params, busy timeout and WAL journal are enabled and transactions take write lock immediately
+// so that concurrent writers wait for each other instead of failing. Foreign keys are enforced.
+func SQLiteDSN(dsn string) (string, error) {
+	if !IsSQLite(dsn) {
+		return "", fmt.Errorf("invalid sqlite dsn %q: expected %s scheme", dsn, sqliteScheme)
+	}
+
+	path, rawQuery := strings.TrimPrefix(dsn, sqliteScheme), ""
+	if i := strings.IndexByte(path, '?'); i != -1 {
+		path, rawQuery = path[:i], path[i+1:]
+	}
+
+	if path == "" {
+		return "", fmt.Errorf("invalid sqlite dsn %q: missing path", dsn)
+	}
+
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid sqlite dsn %q: %w", dsn, err)
+	}
+
+	for key, value := range map[string]string{
+		"_busy_timeout": "5000",
+		"_foreign_keys": "1",
+		"_journal_mode": "WAL",
+		"_txlock":       "immediate",
+	} {
+		if params.Get(key) == "" {
+			params.Set(key, value)
+		}
+	}
+
+	return "file:" + path + "?" + params.Encode(), nil
+}
@@ -0,0 +1,130 @@
+// Package health serves liveness and readiness probes.
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/goes-funky/httprouter"
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+)
+
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+	statusShutdown    = "shutting down"
+)
+
+// CheckFunc returns error if dependency is not ready to serve requests.
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Response is returned by health endpoints.
+type Response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Checker reports the process as ready when all checks pass and shutdown was not started.
+type Checker struct {
+	logger   *zap.Logger
+	timeout  time.Duration
+	checks   []check
+	shutdown int32
+}
+
+type Opt func(*Checker)
+
+// Logger used to report failed checks.
+func Logger(logger *zap.Logger) Opt {
+	return func(c *Checker) {
+		c.logger = logger
+	}
+}
+
+// Timeout for running all readiness checks.
+func Timeout(timeout time.Duration) Opt {
+	return func(c *Checker) {
+		c.timeout = timeout
+	}
+}
+
+// Check adds named readiness check, checks run in the order they were added.
+func Check(name string, fn CheckFunc) Opt {
+	return func(c *Checker) {
+		c.checks = append(c.checks, check{name: name, fn: fn})
+	}
+}
+
+func New(opts ...Opt) *Checker {
+	c := &Checker{
+		logger:  zap.NewNop(),
+		timeout: 5 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Shutdown marks the process as not ready so that load balancers stop routing new requests to it.
+func (c *Checker) Shutdown() {
+	atomic.StoreInt32(&c.shutdown, 1)
+}
+
+func (c *Checker) RegisterRoutes(router *routes.Mux) {
+	router.Handler(http.MethodGet, LivenessPath, c.liveness)
+	router.Handler(http.MethodGet, ReadinessPath, c.readiness)
+}
+
+func (c *Checker) liveness(w http.ResponseWriter, req *http.Request) error {
+	return httprouter.JSONResponse(w, http.StatusOK, Response{Status: statusOK})
+}
+
+func (c *Checker) readiness(w http.ResponseWriter, req *http.Request) error {
+	if atomic.LoadInt32(&c.shutdown) == 1 {
+		return httprouter.JSONResponse(w, http.StatusServiceUnavailable, Response{Status: statusShutdown})
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+	defer cancel()
+
+	res := Response{
+		Status: statusOK,
+		Checks: make(map[string]string, len(c.checks)),
+	}
+
+	for _, check := range c.checks {
+		if err := check.fn(ctx); err != nil {
+			logging.Logger(ctx, c.logger).Warn("readiness check failed", zap.String("check", check.name), zap.Error(err))
+
+			res.Status = statusUnavailable
+			res.Checks[check.name] = statusUnavailable
+
+			continue
+		}
+
+		res.Checks[check.name] = statusOK
+	}
+
+	status := http.StatusOK
+	if res.Status != statusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	return httprouter.JSONResponse(w, status, res)
+}
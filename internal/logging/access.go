@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+)
+
+// AccessLog logs each request with route template, status, duration and response size.
+// Fields carried in request context such as request ID are included.
+func AccessLog(logger *zap.Logger) routes.Middleware {
+	return func(method, path string, next routes.Handler) routes.Handler {
+		return func(w http.ResponseWriter, req *http.Request) error {
+			start := time.Now()
+			rw := routes.NewResponseWriter(w)
+
+			err := next(rw, req)
+
+			status := rw.Status()
+			switch {
+			case status == 0 && err != nil:
+				status = http.StatusInternalServerError
+			case status == 0:
+				status = http.StatusOK
+			}
+
+			Logger(req.Context(), logger).Info("request",
+				zap.String("method", method),
+				zap.String("route", path),
+				zap.String("path", req.URL.Path),
+				zap.Int("status", status),
+				zap.Duration("duration", time.Since(start)),
+				zap.Int64("size", rw.Size()),
+				zap.String("remote_addr", req.RemoteAddr),
+				zap.String("user_agent", req.UserAgent()),
+			)
+
+			return err
+		}
+	}
+}
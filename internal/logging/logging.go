@@ -0,0 +1,37 @@
+// Package logging carries request scoped zap fields in context.
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type fieldsKey struct{}
+
+// WithFields returns context carrying fields in addition to fields already present in ctx.
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	existing := Fields(ctx)
+
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// Fields returns fields carried by ctx.
+func Fields(ctx context.Context) []zap.Field {
+	fields, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	return fields
+}
+
+// Logger returns logger with fields carried by ctx.
+func Logger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	fields := Fields(ctx)
+	if len(fields) == 0 {
+		return logger
+	}
+
+	return logger.With(fields...)
+}
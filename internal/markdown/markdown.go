@@ -0,0 +1,43 @@
+// Package markdown renders user supplied markdown to HTML that is safe to embed in pages.
+package markdown
+
+import (
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/russross/blackfriday/v2"
+)
+
+// policy allows elements of user generated content that can't run scripts, it is safe for concurrent use.
+var policy = newPolicy()
+
+// Render converts markdown to sanitized HTML fragment.
+// Raw HTML in the source is skipped by the renderer, unsafe links are removed by sanitizer keeping their text.
+func Render(src string) string {
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.SkipHTML | blackfriday.SkipImages,
+	})
+
+	out := blackfriday.Run([]byte(src),
+		blackfriday.WithRenderer(renderer),
+		blackfriday.WithExtensions(blackfriday.CommonExtensions),
+	)
+
+	return Sanitize(string(out))
+}
+
+// Sanitize keeps only elements and attributes of HTML fragment allowed in user generated content.
+// Links are restricted to http, https, mailto and relative URLs and are not followed by crawlers.
+func Sanitize(fragment string) string {
+	return policy.Sanitize(fragment)
+}
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	// language of fenced code blocks for syntax highlighting
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+
+	return p
+}
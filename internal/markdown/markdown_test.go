@@ -0,0 +1,73 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/markdown"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+		expected string
+	}{
+		{name: "script", fragment: `<script>alert(1)</script>text`, expected: "text"},
+		{name: "nested script", fragment: `<script><script>alert(1)</script></script>`, expected: ""},
+		{name: "script split by script", fragment: `<scr<script>ipt>alert(1)</scr</script>ipt>`, expected: "ipt&gt;alert(1)ipt&gt;"},
+		{name: "unclosed script", fragment: `<script>alert(1)`, expected: ""},
+		{name: "javascript link", fragment: `<a href="javascript:alert(1)">x</a>`, expected: "x"},
+		{name: "javascript link with mixed case", fragment: `<a href="JaVaScRiPt:alert(1)">x</a>`, expected: "x"},
+		{name: "javascript link with leading whitespace", fragment: `<a href=" javascript:alert(1)">x</a>`, expected: "x"},
+		{name: "javascript link with tab entity", fragment: `<a href="jav&#x09;ascript:alert(1)">x</a>`, expected: "x"},
+		{name: "javascript link with newline", fragment: "<a href=\"java\nscript:alert(1)\">x</a>", expected: "x"},
+		{name: "javascript link with entities", fragment: `<a href="&#106;&#97;&#118;&#97;&#115;&#99;&#114;&#105;&#112;&#116;&#58;alert(1)">x</a>`, expected: "x"},
+		{name: "data link", fragment: `<a href="data:text/html;base64,PHNjcmlwdD4=">x</a>`, expected: "x"},
+		{name: "svg onload", fragment: `<svg onload=alert(1)>`, expected: ""},
+		{name: "script in svg", fragment: `<svg><script>alert(1)</script></svg>`, expected: ""},
+		{name: "image onerror", fragment: `<img src=x onerror=alert(1)>`, expected: `<img src="x">`},
+		{name: "event handler", fragment: `<p onclick="alert(1)">text</p>`, expected: "<p>text</p>"},
+		{name: "iframe", fragment: `<iframe src="https://example.com"></iframe>`, expected: ""},
+		{name: "style", fragment: `<style>body{}</style>text`, expected: "text"},
+		{name: "unclosed tag", fragment: `<a href="javascript:alert(1)"`, expected: ""},
+		{name: "unclosed elements", fragment: `<p onclick="alert(1)">unclosed <b>bold`, expected: "<p>unclosed <b>bold"},
+		{name: "link", fragment: `<a href="https://example.com" title="t">x</a>`, expected: `<a href="https://example.com" title="t" rel="nofollow noreferrer">x</a>`},
+		{name: "mailto link", fragment: `<a href="mailto:todo@example.com">x</a>`, expected: `<a href="mailto:todo@example.com" rel="nofollow noreferrer">x</a>`},
+		{name: "code language", fragment: `<code class="language-go">x</code>`, expected: `<code class="language-go">x</code>`},
+		{name: "code class", fragment: `<code class="evil">x</code>`, expected: "<code>x</code>"},
+		{name: "table cell alignment", fragment: `<td align="center">x</td>`, expected: `<td align="center">x</td>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := markdown.Sanitize(tt.fragment); actual != tt.expected {
+				t.Errorf("expected %q sanitized as %q, got %q", tt.fragment, tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{name: "emphasis", src: "**milk** and *eggs*", expected: "<p><strong>milk</strong> and <em>eggs</em></p>\n"},
+		{name: "raw html", src: "<script>alert(1)</script>", expected: "<p>alert(1)</p>\n"},
+		{name: "inline raw html", src: `text <img src=x onerror=alert(1)>`, expected: "<p>text </p>\n"},
+		{name: "link", src: "[x](https://example.com)", expected: "<p><a href=\"https://example.com\" rel=\"nofollow noreferrer\">x</a></p>\n"},
+		{name: "javascript link", src: "[x](javascript:void)", expected: "<p>x</p>\n"},
+		{name: "javascript link with entities", src: "[x](&#106;avascript:void)", expected: "<p>x</p>\n"},
+		{name: "image", src: "![x](https://example.com/x.png)", expected: "<p></p>\n"},
+		{name: "fenced code", src: "```go\nx\n```", expected: "<pre><code class=\"language-go\">x\n</code></pre>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := markdown.Render(tt.src); actual != tt.expected {
+				t.Errorf("expected %q rendered as %q, got %q", tt.src, tt.expected, actual)
+			}
+		})
+	}
+}
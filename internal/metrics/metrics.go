@@ -0,0 +1,92 @@
+// Package metrics instruments HTTP handlers and database queries with Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtx"
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+)
+
+// Metrics collects HTTP request and database query latencies.
+type Metrics struct {
+	httpDuration  *prometheus.HistogramVec
+	queryDuration *prometheus.HistogramVec
+}
+
+// New creates metrics and registers them in registerer.
+func New(registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests by route template.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of database queries by sqlc query name.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"query", "op", "status"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.httpDuration, m.queryDuration} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("failed to register metrics: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+// Middleware observes duration of requests labelled by route template rather than request path to keep cardinality bounded.
+// It should be the outermost middleware to observe status of rendered errors.
+func (m *Metrics) Middleware() routes.Middleware {
+	return func(method, path string, next routes.Handler) routes.Handler {
+		return func(w http.ResponseWriter, req *http.Request) error {
+			start := time.Now()
+			rw := routes.NewResponseWriter(w)
+
+			err := next(rw, req)
+
+			status := rw.Status()
+			switch {
+			case status == 0 && err != nil:
+				// error was not rendered by inner middleware
+				status = http.StatusInternalServerError
+			case status == 0:
+				status = http.StatusOK
+			}
+
+			m.httpDuration.WithLabelValues(method, path, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
+
+// Interceptor observes duration of queries labelled by sqlc query name.
+func (m *Metrics) Interceptor() dbtx.Interceptor {
+	return func(ctx context.Context, query dbtx.Query, next dbtx.Next) (dbtx.Result, error) {
+		start := time.Now()
+		res, err := next(ctx)
+
+		status := "ok"
+		switch {
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			status = "canceled"
+		case err != nil:
+			status = "error"
+		}
+
+		m.queryDuration.WithLabelValues(query.Name, query.Op, status).Observe(time.Since(start).Seconds())
+
+		return res, err
+	}
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStatsCollector exports connection pool statistics of *pgxpool.Pool.
+type PoolStatsCollector struct {
+	pool *pgxpool.Pool
+
+	maxConns             *prometheus.Desc
+	totalConns           *prometheus.Desc
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+}
+
+// NewPoolStatsCollector creates collector of pgx pool statistics, metrics are labelled with database name.
+func NewPoolStatsCollector(pool *pgxpool.Pool, name string) *PoolStatsCollector {
+	labels := prometheus.Labels{"db_name": name}
+
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("pgxpool", "", name), help, nil, labels)
+	}
+
+	return &PoolStatsCollector{
+		pool:                 pool,
+		maxConns:             desc("max_connections", "Maximum size of the pool."),
+		totalConns:           desc("connections", "The number of connections currently in the pool, including constructing ones."),
+		acquiredConns:        desc("acquired_connections", "The number of connections currently acquired."),
+		idleConns:            desc("idle_connections", "The number of idle connections."),
+		constructingConns:    desc("constructing_connections", "The number of connections currently being established."),
+		acquireCount:         desc("acquire_count_total", "The total number of successful connection acquires."),
+		acquireDuration:      desc("acquire_duration_seconds_total", "The total time spent acquiring connections."),
+		emptyAcquireCount:    desc("empty_acquire_count_total", "The total number of acquires that waited for a connection because the pool was empty."),
+		canceledAcquireCount: desc("canceled_acquire_count_total", "The total number of acquires canceled by context."),
+	}
+}
+
+func (c *PoolStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxConns
+	ch <- c.totalConns
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquireCount
+}
+
+func (c *PoolStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stats.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stats.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stats.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stats.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stats.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stats.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stats.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stats.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stats.CanceledAcquireCount()))
+}
@@ -0,0 +1,86 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/api"
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+	"github.com/shaxbee/todo-app-skaffold/internal/requestid"
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+)
+
+// Middleware renders errors returned by handlers as problem details.
+// Server errors are logged, their cause is exposed to clients only in verbose mode.
+func Middleware(logger *zap.Logger, verbose bool) routes.Middleware {
+	return func(method, path string, next routes.Handler) routes.Handler {
+		return func(w http.ResponseWriter, req *http.Request) error {
+			rw := routes.NewResponseWriter(w)
+
+			err := next(rw, req)
+			if err == nil {
+				return nil
+			}
+
+			p := From(err)
+
+			if p.Status >= http.StatusInternalServerError {
+				logging.Logger(req.Context(), logger).Error("request failed",
+					zap.String("method", method),
+					zap.String("route", path),
+					zap.String("path", req.URL.Path),
+					zap.Error(err),
+				)
+			}
+
+			// response was already started, problem can't be rendered anymore
+			if rw.Written() {
+				return nil
+			}
+
+			Write(w, req, p, verbose)
+
+			return nil
+		}
+	}
+}
+
+// Write renders problem as application/problem+json response.
+func Write(w http.ResponseWriter, req *http.Request, p *Problem, verbose bool) {
+	res := api.Problem{
+		Type:   p.Type,
+		Title:  p.Title,
+		Status: int32(p.Status),
+	}
+
+	if p.Detail != "" {
+		res.SetDetail(p.Detail)
+	}
+
+	if req != nil {
+		res.SetInstance(req.URL.RequestURI())
+
+		if id := requestid.FromContext(req.Context()); id != "" {
+			res.SetRequestId(id)
+		}
+	}
+
+	for _, fe := range p.Errors {
+		res.Errors = append(res.Errors, api.ProblemFieldError{
+			Field:   fe.Field,
+			Message: fe.Message,
+		})
+	}
+
+	if verbose && p.cause != nil {
+		res.SetDebug(p.cause.Error())
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+
+	_ = json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,169 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ContentType of problem details responses as defined by RFC 7807.
+const ContentType = "application/problem+json"
+
+// Problem type URIs are stable and can be used by clients to identify problems.
+const (
+	TypeValidation           = "/problems/validation"
+	TypeMalformedRequest     = "/problems/malformed-request"
+	TypeNotFound             = "/problems/not-found"
+	TypeConflict             = "/problems/conflict"
+	TypeUnsupportedMediaType = "/problems/unsupported-media-type"
+	TypePayloadTooLarge      = "/problems/payload-too-large"
+	TypeRateLimited          = "/problems/rate-limited"
+	TypeInternal             = "/problems/internal"
+)
+
+var titles = map[string]string{
+	TypeValidation:           "Validation failed",
+	TypeMalformedRequest:     "Malformed request",
+	TypeNotFound:             "Not found",
+	TypeConflict:             "Conflict",
+	TypeUnsupportedMediaType: "Unsupported media type",
+	TypePayloadTooLarge:      "Payload too large",
+	TypeRateLimited:          "Rate limit exceeded",
+	TypeInternal:             "Internal server error",
+}
+
+// FieldError describes validation problem of a single field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (fe FieldError) String() string {
+	return fe.Field + ": " + fe.Message
+}
+
+// Problem is an error rendered as problem details response.
+type Problem struct {
+	Type   string
+	Title  string
+	Status int
+	Detail string
+	Errors []FieldError
+	cause  error
+}
+
+type Opt func(*Problem)
+
+// Detail sets human readable explanation specific to this occurrence of the problem.
+func Detail(detail string) Opt {
+	return func(p *Problem) {
+		p.Detail = detail
+	}
+}
+
+// Detailf sets formatted detail.
+func Detailf(format string, args ...interface{}) Opt {
+	return func(p *Problem) {
+		p.Detail = fmt.Sprintf(format, args...)
+	}
+}
+
+// Field adds field level validation problem.
+func Field(field, message string) Opt {
+	return func(p *Problem) {
+		p.Errors = append(p.Errors, FieldError{Field: field, Message: message})
+	}
+}
+
+// Fields adds field level validation problems.
+func Fields(fieldErrs ...FieldError) Opt {
+	return func(p *Problem) {
+		p.Errors = append(p.Errors, fieldErrs...)
+	}
+}
+
+// Cause sets underlying error, it's exposed to clients only in verbose mode.
+func Cause(err error) Opt {
+	return func(p *Problem) {
+		p.cause = err
+	}
+}
+
+// New creates problem of given type, title is derived from well known types.
+func New(status int, typ string, opts ...Opt) *Problem {
+	title, ok := titles[typ]
+	if !ok {
+		title = http.StatusText(status)
+	}
+
+	p := &Problem{
+		Type:   typ,
+		Title:  title,
+		Status: status,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func Validation(opts ...Opt) *Problem {
+	return New(http.StatusBadRequest, TypeValidation, opts...)
+}
+
+func MalformedRequest(opts ...Opt) *Problem {
+	return New(http.StatusBadRequest, TypeMalformedRequest, opts...)
+}
+
+func NotFound(opts ...Opt) *Problem {
+	return New(http.StatusNotFound, TypeNotFound, opts...)
+}
+
+func Conflict(opts ...Opt) *Problem {
+	return New(http.StatusConflict, TypeConflict, opts...)
+}
+
+func UnsupportedMediaType(opts ...Opt) *Problem {
+	return New(http.StatusUnsupportedMediaType, TypeUnsupportedMediaType, opts...)
+}
+
+func PayloadTooLarge(opts ...Opt) *Problem {
+	return New(http.StatusRequestEntityTooLarge, TypePayloadTooLarge, opts...)
+}
+
+func RateLimited(opts ...Opt) *Problem {
+	return New(http.StatusTooManyRequests, TypeRateLimited, opts...)
+}
+
+func Internal(opts ...Opt) *Problem {
+	return New(http.StatusInternalServerError, TypeInternal, opts...)
+}
+
+// From returns problem carried by err, any other error is an internal problem.
+func From(err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		return p
+	}
+
+	return Internal(Cause(err))
+}
+
+func (p *Problem) Error() string {
+	switch {
+	case p.Detail != "" && p.cause != nil:
+		return p.Detail + ": " + p.cause.Error()
+	case p.Detail != "":
+		return p.Detail
+	case p.cause != nil:
+		return p.Title + ": " + p.cause.Error()
+	default:
+		return p.Title
+	}
+}
+
+func (p *Problem) Unwrap() error {
+	return p.cause
+}
@@ -0,0 +1,173 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit of token bucket refilled at Rate tokens per second up to Burst tokens.
+// Zero limit disables rate limiting.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// UnmarshalText parses limit in RATE/UNIT[,BURST] format such as 10/s or 100/m,20.
+// Unit is one of s, m or h, burst defaults to number of requests per unit.
+func (l *Limit) UnmarshalText(text []byte) error {
+	value := strings.TrimSpace(string(text))
+	if value == "" {
+		*l = Limit{}
+		return nil
+	}
+
+	spec, burstSpec := value, ""
+	if i := strings.IndexByte(value, ','); i != -1 {
+		spec, burstSpec = value[:i], value[i+1:]
+	}
+
+	i := strings.IndexByte(spec, '/')
+	if i == -1 {
+		return fmt.Errorf("invalid rate limit %q: expected RATE/UNIT[,BURST]", value)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(spec[:i]))
+	if err != nil || count <= 0 {
+		return fmt.Errorf("invalid rate limit %q: rate should be a positive integer", value)
+	}
+
+	var unit time.Duration
+
+	switch strings.TrimSpace(spec[i+1:]) {
+	case "s":
+		unit = time.Second
+	case "m":
+		unit = time.Minute
+	case "h":
+		unit = time.Hour
+	default:
+		return fmt.Errorf("invalid rate limit %q: unit should be one of s, m or h", value)
+	}
+
+	burst := count
+	if burstSpec != "" {
+		burst, err = strconv.Atoi(strings.TrimSpace(burstSpec))
+		if err != nil || burst <= 0 {
+			return fmt.Errorf("invalid rate limit %q: burst should be a positive integer", value)
+		}
+	}
+
+	*l = Limit{
+		Rate:  float64(count) / unit.Seconds(),
+		Burst: burst,
+	}
+
+	return nil
+}
+
+// Enabled reports whether limit is set.
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+func (l Limit) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l Limit) String() string {
+	if !l.Enabled() {
+		return ""
+	}
+
+	for _, unit := range []struct {
+		name     string
+		duration time.Duration
+	}{
+		{name: "s", duration: time.Second},
+		{name: "m", duration: time.Minute},
+		{name: "h", duration: time.Hour},
+	} {
+		count := l.Rate * unit.duration.Seconds()
+		if count >= 1 && count == math.Trunc(count) {
+			return strconv.Itoa(int(count)) + "/" + unit.name + "," + strconv.Itoa(l.Burst)
+		}
+	}
+
+	return strconv.FormatFloat(l.Rate, 'f', -1, 64) + "/s," + strconv.Itoa(l.Burst)
+}
+
+// Rules are limits of routes keyed by method and route template such as "POST /api/v1/todo".
+type Rules map[string]Limit
+
+// UnmarshalText parses rules separated by semicolon in METHOD PATH=LIMIT format, such as "POST /api/v1/todo=10/m,20".
+func (r *Rules) UnmarshalText(text []byte) error {
+	rules := make(Rules)
+
+	for _, rule := range strings.Split(string(text), ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		i := strings.LastIndexByte(rule, '=')
+		if i == -1 {
+			return fmt.Errorf("invalid rate limit rule %q: expected METHOD PATH=LIMIT", rule)
+		}
+
+		route := strings.Join(strings.Fields(rule[:i]), " ")
+		if len(strings.Fields(route)) != 2 {
+			return fmt.Errorf("invalid rate limit rule %q: expected METHOD PATH=LIMIT", rule)
+		}
+
+		var limit Limit
+		if err := limit.UnmarshalText([]byte(rule[i+1:])); err != nil {
+			return err
+		}
+
+		rules[route] = limit
+	}
+
+	*r = rules
+
+	return nil
+}
+
+func (r Rules) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r Rules) String() string {
+	routes := make([]string, 0, len(r))
+	for route := range r {
+		routes = append(routes, route)
+	}
+
+	sort.Strings(routes)
+
+	rules := make([]string, len(routes))
+	for i, route := range routes {
+		rules[i] = route + "=" + r[route].String()
+	}
+
+	return strings.Join(rules, ";")
+}
+
+// take refills bucket with tokens accumulated since last update and takes one token if available.
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, Result) {
+	tokens = math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	return tokens, result(tokens, allowed, limit)
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,80 @@
+package ratelimit_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/ratelimit"
+)
+
+func TestLimit(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected ratelimit.Limit
+		str      string
+	}{
+		{text: "10/s", expected: ratelimit.Limit{Rate: 10, Burst: 10}, str: "10/s,10"},
+		{text: "120/m,20", expected: ratelimit.Limit{Rate: 2, Burst: 20}, str: "2/s,20"},
+		{text: " 100 / m , 5 ", expected: ratelimit.Limit{Rate: 100.0 / 60, Burst: 5}, str: "100/m,5"},
+		{text: "36/h", expected: ratelimit.Limit{Rate: 0.01, Burst: 36}, str: "36/h,36"},
+		{text: "", expected: ratelimit.Limit{}, str: ""},
+	}
+
+	for _, tt := range tests {
+		var actual ratelimit.Limit
+		if err := actual.UnmarshalText([]byte(tt.text)); err != nil {
+			t.Errorf("failed to parse %q: %v", tt.text, err)
+			continue
+		}
+
+		if actual != tt.expected {
+			t.Errorf("expected %q to parse as %+v, got %+v", tt.text, tt.expected, actual)
+		}
+
+		if actual.String() != tt.str {
+			t.Errorf("expected %+v to format as %q, got %q", actual, tt.str, actual.String())
+		}
+
+		var parsed ratelimit.Limit
+		if err := parsed.UnmarshalText([]byte(actual.String())); err != nil || parsed != actual {
+			t.Errorf("expected %q to round trip, got %+v: %v", actual.String(), parsed, err)
+		}
+	}
+}
+
+func TestLimitInvalid(t *testing.T) {
+	for _, text := range []string{"10", "10/d", "0/s", "-1/s", "x/s", "10/s,0", "10/s,x"} {
+		var limit ratelimit.Limit
+		if err := limit.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("expected %q to be rejected, got %+v", text, limit)
+		}
+	}
+}
+
+func TestRules(t *testing.T) {
+	var rules ratelimit.Rules
+	if err := rules.UnmarshalText([]byte(" POST  /api/v1/todo=10/m,20; ;GET /api/v1/todo/:id=5/s")); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ratelimit.Rules{
+		"POST /api/v1/todo":    {Rate: 10.0 / 60, Burst: 20},
+		"GET /api/v1/todo/:id": {Rate: 5, Burst: 5},
+	}
+
+	if diff := cmp.Diff(expected, rules); diff != "" {
+		t.Error("expected equal rules:", diff)
+	}
+
+	if actual, expected := rules.String(), "GET /api/v1/todo/:id=5/s,5;POST /api/v1/todo=10/m,20"; actual != expected {
+		t.Errorf("expected rules formatted as %q, got %q", expected, actual)
+	}
+
+	for _, text := range []string{"POST /api/v1/todo", "/api/v1/todo=10/s", "POST /api/v1/todo=10"} {
+		var rules ratelimit.Rules
+		if err := rules.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("expected %q to be rejected", text)
+		}
+	}
+}
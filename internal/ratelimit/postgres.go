@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtx"
+)
+
+// takeQuery refills and takes token in a single upsert using database clock so that replicas share buckets.
+// New bucket is created full with one token taken, existing bucket is refilled from the row locked by the conflict
+// so that concurrent requests, including the first requests for a key, are serialized.
+const takeQuery = `
+INSERT INTO rate_limit AS b (key, tokens, allowed, updated_at, expires_at)
+VALUES ($1, $2::float8 - 1, true, clock_timestamp(), clock_timestamp() + INTERVAL '1 second' / $3::float8)
+ON CONFLICT (key) DO UPDATE SET (tokens, allowed, updated_at, expires_at) = (
+    SELECT taken.tokens, taken.allowed, taken.now, taken.now + ($2::float8 - taken.tokens) / $3::float8 * INTERVAL '1 second'
+    FROM (
+        SELECT refill.tokens >= 1 AS allowed, CASE WHEN refill.tokens >= 1 THEN refill.tokens - 1 ELSE refill.tokens END AS tokens, refill.now
+        FROM (
+            SELECT LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM clock_timestamp() - b.updated_at) * $3::float8) AS tokens, clock_timestamp() AS now
+        ) refill
+    ) taken
+)
+RETURNING tokens, allowed
+`
+
+// cleanupQuery removes buckets that are full again, they are recreated full on next use.
+const cleanupQuery = `DELETE FROM rate_limit WHERE expires_at < clock_timestamp()`
+
+// PostgresStore keeps buckets in rate_limit table shared by all replicas.
+type PostgresStore struct {
+	db dbtx.DBTX
+}
+
+func NewPostgresStore(db dbtx.DBTX) *PostgresStore {
+	return &PostgresStore{
+		db: db,
+	}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	var (
+		tokens  float64
+		allowed bool
+	)
+
+	if err := s.db.QueryRow(ctx, takeQuery, key, limit.Burst, limit.Rate).Scan(&tokens, &allowed); err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	return result(tokens, allowed, limit), nil
+}
+
+// Cleanup removes expired buckets and returns number of buckets removed.
+func (s *PostgresStore) Cleanup(ctx context.Context) (int64, error) {
+	res, err := s.db.Exec(ctx, cleanupQuery)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup rate limits: %w", err)
+	}
+
+	return res.RowsAffected(), nil
+}
+
+func result(tokens float64, allowed bool, limit Limit) Result {
+	res := Result{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+
+	return res
+}
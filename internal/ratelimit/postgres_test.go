@@ -0,0 +1,102 @@
+//go:build integration
+// +build integration
+
+package ratelimit_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtest"
+	"github.com/shaxbee/todo-app-skaffold/internal/dbutil"
+	"github.com/shaxbee/todo-app-skaffold/internal/ratelimit"
+)
+
+func TestPostgresStore(t *testing.T) {
+	ctx := context.Background()
+
+	pool, err := dbutil.OpenPool(ctx, dbtest.SetupPostgresDSN(t, dbtest.Migration("../../services/todo/migrations")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	store := ratelimit.NewPostgresStore(pool)
+	// refill is negligible during the test
+	limit := ratelimit.Limit{Rate: 0.001, Burst: 5}
+
+	t.Run("concurrent first requests", func(t *testing.T) {
+		var (
+			wg      sync.WaitGroup
+			allowed int32
+		)
+
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				res, err := store.Take(ctx, "concurrent", limit)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				if res.Allowed {
+					atomic.AddInt32(&allowed, 1)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if int(allowed) != limit.Burst {
+			t.Errorf("expected %d requests allowed, got %d", limit.Burst, allowed)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		for i := 0; i < limit.Burst; i++ {
+			res, err := store.Take(ctx, "rejected", limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !res.Allowed || res.Remaining != limit.Burst-1-i {
+				t.Fatalf("expected request %d allowed, got %+v", i, res)
+			}
+		}
+
+		res, err := store.Take(ctx, "rejected", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.Allowed || res.Remaining != 0 || res.RetryAfter <= 0 {
+			t.Errorf("expected request over burst rejected, got %+v", res)
+		}
+	})
+
+	t.Run("cleanup", func(t *testing.T) {
+		if _, err := store.Take(ctx, "cleanup", ratelimit.Limit{Rate: 1000, Burst: 1}); err != nil {
+			t.Fatal(err)
+		}
+
+		// bucket of a single token is full again after 1ms
+		if _, err := pool.Exec(ctx, "SELECT pg_sleep(0.01)"); err != nil {
+			t.Fatal(err)
+		}
+
+		n, err := store.Cleanup(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n < 1 {
+			t.Errorf("expected expired bucket removed, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+// Package ratelimit limits request rate per client using token buckets.
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// SubjectFunc returns authenticated subject of the request or empty string.
+type SubjectFunc func(req *http.Request) string
+
+type Limiter struct {
+	store             Store
+	logger            *zap.Logger
+	defaultLimit      Limit
+	rules             Rules
+	subject           SubjectFunc
+	trustForwardedFor bool
+}
+
+type Opt func(*Limiter)
+
+func Logger(logger *zap.Logger) Opt {
+	return func(l *Limiter) {
+		l.logger = logger
+	}
+}
+
+// Default sets limit of routes without a rule, zero limit leaves them unlimited.
+func Default(limit Limit) Opt {
+	return func(l *Limiter) {
+		l.defaultLimit = limit
+	}
+}
+
+// Routes sets limits of individual routes.
+func Routes(rules Rules) Opt {
+	return func(l *Limiter) {
+		l.rules = rules
+	}
+}
+
+// Subject sets function identifying authenticated clients.
+func Subject(fn SubjectFunc) Opt {
+	return func(l *Limiter) {
+		l.subject = fn
+	}
+}
+
+// TrustForwardedFor identifies anonymous clients by the first address in X-Forwarded-For header.
+// It should be enabled only behind a proxy that overwrites the header.
+func TrustForwardedFor(trust bool) Opt {
+	return func(l *Limiter) {
+		l.trustForwardedFor = trust
+	}
+}
+
+func New(store Store, opts ...Opt) *Limiter {
+	l := &Limiter{
+		store:  store,
+		logger: zap.NewNop(),
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Middleware takes a token for every request to routes with a limit and rejects requests once the bucket is empty.
+// Limits are tracked per route and client identified by API key, authenticated subject or client IP in that order.
+// Requests are allowed when store fails so that rate limiting doesn't take the service down.
+func (l *Limiter) Middleware() routes.Middleware {
+	return func(method, path string, next routes.Handler) routes.Handler {
+		route := method + " " + path
+
+		limit, ok := l.rules[route]
+		if !ok {
+			limit = l.defaultLimit
+		}
+
+		if !limit.Enabled() {
+			return next
+		}
+
+		return func(w http.ResponseWriter, req *http.Request) error {
+			ctx := req.Context()
+
+			res, err := l.store.Take(ctx, route+" "+l.clientKey(req), limit)
+			if err != nil {
+				logging.Logger(ctx, l.logger).Warn("rate limit", zap.String("route", route), zap.Error(err))
+				return next(w, req)
+			}
+
+			header := w.Header()
+			header.Set(HeaderLimit, strconv.Itoa(res.Limit))
+			header.Set(HeaderRemaining, strconv.Itoa(res.Remaining))
+			header.Set(HeaderReset, strconv.Itoa(ceilSeconds(res.Reset)))
+
+			if !res.Allowed {
+				retryAfter := ceilSeconds(res.RetryAfter)
+				header.Set(HeaderRetryAfter, strconv.Itoa(retryAfter))
+
+				return problem.RateLimited(problem.Detailf("rate limit exceeded, retry in %d seconds", retryAfter))
+			}
+
+			return next(w, req)
+		}
+	}
+}
+
+// clientKey identifies client, API keys are hashed so that they are not stored in plain text.
+func (l *Limiter) clientKey(req *http.Request) string {
+	if key := req.Header.Get(APIKeyHeader); key != "" {
+		sum := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(sum[:])
+	}
+
+	if l.subject != nil {
+		if subject := l.subject(req); subject != "" {
+			return "sub:" + subject
+		}
+	}
+
+	return "ip:" + l.clientIP(req)
+}
+
+func (l *Limiter) clientIP(req *http.Request) string {
+	if l.trustForwardedFor {
+		if forwarded := req.Header.Get("X-Forwarded-For"); forwarded != "" {
+			if i := strings.IndexByte(forwarded, ','); i != -1 {
+				forwarded = forwarded[:i]
+			}
+
+			return strings.TrimSpace(forwarded)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
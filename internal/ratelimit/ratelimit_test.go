@@ -0,0 +1,133 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/internal/ratelimit"
+)
+
+func TestMiddleware(t *testing.T) {
+	limit := ratelimit.Limit{Rate: 0.001, Burst: 2}
+
+	next := func(w http.ResponseWriter, req *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	serve := func(handler func(http.ResponseWriter, *http.Request) error, header http.Header) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todo", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+
+		for name, values := range header {
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
+		}
+
+		rec := httptest.NewRecorder()
+		err := handler(rec, req)
+
+		return rec, err
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		limiter := ratelimit.New(ratelimit.NewMemoryStore(), ratelimit.Default(limit))
+		handler := limiter.Middleware()(http.MethodGet, "/api/v1/todo", next)
+
+		for i := 0; i < limit.Burst; i++ {
+			rec, err := serve(handler, nil)
+			if err != nil {
+				t.Fatalf("expected request %d allowed: %v", i, err)
+			}
+
+			if remaining := rec.Header().Get(ratelimit.HeaderRemaining); remaining != []string{"1", "0"}[i] {
+				t.Errorf("unexpected remaining tokens %q", remaining)
+			}
+		}
+
+		rec, err := serve(handler, nil)
+
+		var p *problem.Problem
+		if !errors.As(err, &p) || p.Status != http.StatusTooManyRequests {
+			t.Fatalf("expected request over burst to be rate limited, got %v", err)
+		}
+
+		expected := make(http.Header)
+		expected.Set(ratelimit.HeaderLimit, "2")
+		expected.Set(ratelimit.HeaderRemaining, "0")
+		expected.Set(ratelimit.HeaderReset, "2000")
+		expected.Set(ratelimit.HeaderRetryAfter, "1000")
+
+		if diff := cmp.Diff(expected, rec.Header()); diff != "" {
+			t.Error("expected rate limit headers:", diff)
+		}
+	})
+
+	t.Run("clients", func(t *testing.T) {
+		limiter := ratelimit.New(ratelimit.NewMemoryStore(),
+			ratelimit.Default(ratelimit.Limit{Rate: 0.001, Burst: 1}),
+			ratelimit.TrustForwardedFor(true),
+			ratelimit.Subject(func(req *http.Request) string { return req.Header.Get("X-Subject") }),
+		)
+		handler := limiter.Middleware()(http.MethodGet, "/api/v1/todo", next)
+
+		// each client has its own bucket of a single token
+		for _, header := range []http.Header{
+			nil,
+			{"X-Forwarded-For": {"198.51.100.1, 192.0.2.1"}},
+			{"X-Subject": {"alice"}},
+			{ratelimit.APIKeyHeader: {"secret"}},
+			{ratelimit.APIKeyHeader: {"secret"}, "X-Subject": {"bob"}},
+		} {
+			_, err := serve(handler, header)
+			if header[ratelimit.APIKeyHeader] != nil && header["X-Subject"] != nil {
+				// API key takes precedence over subject
+				if err == nil {
+					t.Errorf("expected client with %v identified by API key", header)
+				}
+
+				continue
+			}
+
+			if err != nil {
+				t.Errorf("expected first request of client with %v allowed: %v", header, err)
+			}
+		}
+	})
+
+	t.Run("rules", func(t *testing.T) {
+		limiter := ratelimit.New(ratelimit.NewMemoryStore(),
+			ratelimit.Routes(ratelimit.Rules{"POST /api/v1/todo": {Rate: 0.001, Burst: 1}}),
+		)
+
+		handler := limiter.Middleware()(http.MethodGet, "/api/v1/todo", next)
+		for i := 0; i < 5; i++ {
+			if _, err := serve(handler, nil); err != nil {
+				t.Fatalf("expected route without rule and default limit to be unlimited: %v", err)
+			}
+		}
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		limiter := ratelimit.New(failingStore{}, ratelimit.Default(limit))
+		handler := limiter.Middleware()(http.MethodGet, "/api/v1/todo", next)
+
+		rec, err := serve(handler, nil)
+		if err != nil || rec.Code != http.StatusNoContent {
+			t.Errorf("expected request allowed when store fails, got %d: %v", rec.Code, err)
+		}
+	})
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
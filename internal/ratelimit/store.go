@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Result of taking a token from the bucket.
+type Result struct {
+	Allowed bool
+	// Limit is the bucket capacity.
+	Limit int
+	// Remaining tokens in the bucket.
+	Remaining int
+	// RetryAfter is time until next token is available when request is not allowed.
+	RetryAfter time.Duration
+	// Reset is time until the bucket is full again.
+	Reset time.Duration
+}
+
+// Store keeps token buckets.
+type Store interface {
+	// Take takes one token from the bucket identified by key.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// expires is the time bucket is full again and can be forgotten.
+	expires time.Time
+}
+
+// MemoryStore keeps buckets in memory of single replica.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+const sweepInterval = time.Minute
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	tokens, res := take(b.tokens, now.Sub(b.updated), limit)
+	b.tokens, b.updated, b.expires = tokens, now, now.Add(res.Reset)
+
+	return res, nil
+}
+
+// sweep removes expired buckets at most once per sweep interval, they are recreated full on next use.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.expires) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTake(t *testing.T) {
+	limit := Limit{Rate: 2, Burst: 4}
+
+	tests := []struct {
+		name     string
+		tokens   float64
+		elapsed  time.Duration
+		expected float64
+		result   Result
+	}{
+		{
+			name:     "full",
+			tokens:   4,
+			expected: 3,
+			result:   Result{Allowed: true, Limit: 4, Remaining: 3, Reset: 500 * time.Millisecond},
+		},
+		{
+			name:     "refill",
+			tokens:   0.5,
+			elapsed:  time.Second,
+			expected: 1.5,
+			result:   Result{Allowed: true, Limit: 4, Remaining: 1, Reset: 1250 * time.Millisecond},
+		},
+		{
+			name:     "refill capped at burst",
+			tokens:   1,
+			elapsed:  time.Hour,
+			expected: 3,
+			result:   Result{Allowed: true, Limit: 4, Remaining: 3, Reset: 500 * time.Millisecond},
+		},
+		{
+			name:     "empty",
+			tokens:   0.25,
+			elapsed:  250 * time.Millisecond,
+			expected: 0.75,
+			result:   Result{Allowed: false, Limit: 4, Remaining: 0, RetryAfter: 125 * time.Millisecond, Reset: 1625 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, res := take(tt.tokens, tt.elapsed, limit)
+
+			if tokens != tt.expected {
+				t.Errorf("expected %v tokens left, got %v", tt.expected, tokens)
+			}
+
+			if res != tt.result {
+				t.Errorf("expected result %+v, got %+v", tt.result, res)
+			}
+		})
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	// refill is negligible during the test
+	limit := Limit{Rate: 0.001, Burst: 5}
+
+	t.Run("concurrent", func(t *testing.T) {
+		store := NewMemoryStore()
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			allowed int
+		)
+
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				res, err := store.Take(ctx, "key", limit)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				if res.Allowed {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if allowed != limit.Burst {
+			t.Errorf("expected %d requests allowed, got %d", limit.Burst, allowed)
+		}
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		store := NewMemoryStore()
+
+		for i := 0; i < limit.Burst; i++ {
+			if res, _ := store.Take(ctx, "a", limit); !res.Allowed {
+				t.Fatalf("expected request %d allowed", i)
+			}
+		}
+
+		if res, _ := store.Take(ctx, "a", limit); res.Allowed {
+			t.Error("expected request over burst rejected")
+		}
+
+		if res, _ := store.Take(ctx, "b", limit); !res.Allowed || res.Remaining != limit.Burst-1 {
+			t.Errorf("expected bucket of other key to be full, got %+v", res)
+		}
+	})
+
+	t.Run("sweep", func(t *testing.T) {
+		store := NewMemoryStore()
+		fast := Limit{Rate: 1000, Burst: 1}
+
+		if _, err := store.Take(ctx, "a", fast); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := store.Take(ctx, "b", limit); err != nil {
+			t.Fatal(err)
+		}
+
+		// bucket of a is full again after 1ms, bucket of b is not
+		store.sweep(time.Now().Add(sweepInterval))
+
+		if _, ok := store.buckets["a"]; ok {
+			t.Error("expected full bucket to be removed")
+		}
+
+		if _, ok := store.buckets["b"]; !ok {
+			t.Error("expected bucket to be kept until full")
+		}
+	})
+}
@@ -0,0 +1,65 @@
+// Package requestid correlates client requests with server logs using X-Request-ID header.
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+)
+
+const (
+	Header = "X-Request-ID"
+
+	maxLength = 128
+)
+
+type contextKey struct{}
+
+// NewContext returns context carrying request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns request ID carried by ctx or empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Handler accepts request ID sent by client or generates a new one.
+// Request ID is stored in the context, added to logging fields and echoed in response header.
+// It wraps the router so that request ID is present for router level middleware such as request logging.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(Header)
+		if !valid(id) {
+			id = uuid.New().String()
+		}
+
+		w.Header().Set(Header, id)
+
+		ctx := NewContext(req.Context(), id)
+		ctx = logging.WithFields(ctx, zap.String("request_id", id))
+
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// valid accepts IDs made of printable ASCII characters so that they can be safely logged and echoed.
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,65 @@
+package requestid_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+	"github.com/shaxbee/todo-app-skaffold/internal/requestid"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		generated bool
+	}{
+		{name: "client id", header: "client-id-1"},
+		{name: "missing", generated: true},
+		{name: "too long", header: strings.Repeat("a", 129), generated: true},
+		{name: "control characters", header: "id\nforged: header", generated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				id     string
+				fields []zap.Field
+			)
+
+			handler := requestid.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				id = requestid.FromContext(req.Context())
+				fields = logging.Fields(req.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(requestid.Header, tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if tt.generated {
+				if _, err := uuid.Parse(id); err != nil {
+					t.Errorf("expected generated id, got %q", id)
+				}
+			} else if id != tt.header {
+				t.Errorf("expected id %q, got %q", tt.header, id)
+			}
+
+			if header := rec.Header().Get(requestid.Header); header != id {
+				t.Errorf("expected response header %q, got %q", id, header)
+			}
+
+			if len(fields) != 1 || fields[0].Key != "request_id" || fields[0].String != id {
+				t.Errorf("expected request_id logging field, got %v", fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/goes-funky/httprouter"
+)
+
+// Handler has the same signature as httprouter handlers.
+type Handler = func(w http.ResponseWriter, req *http.Request) error
+
+// Middleware wraps handler registered for method and route path.
+// Path is the route template such as /api/v1/todo/:id rather than request path.
+type Middleware func(method, path string, next Handler) Handler
+
+// Mux registers handlers in httprouter wrapped by middleware.
+type Mux struct {
+	router     *httprouter.Router
+	middleware []Middleware
+}
+
+// New creates mux, first middleware is the outermost one.
+func New(router *httprouter.Router, middleware ...Middleware) *Mux {
+	return &Mux{
+		router:     router,
+		middleware: middleware,
+	}
+}
+
+func (m *Mux) Handler(method, path string, handler Handler) {
+	m.router.Handler(method, path, m.wrap(method, path, handler))
+}
+
+// Dispatch registers handlers of static routes such as /api/v1/todo/import sharing position with wildcard of path such as /api/v1/todo/:id.
+// httprouter does not allow static segments next to wildcards when other routes continue after the wildcard.
+// Requests are dispatched by value of the last wildcard, static handlers are wrapped by middleware with their own path
+// so that route rules and metrics keep working, next handles other values.
+func (m *Mux) Dispatch(method, path string, static map[string]Handler, next Handler) {
+	segments := strings.Split(path, "/")
+
+	wildcard := -1
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			wildcard = i
+		}
+	}
+
+	if wildcard == -1 {
+		panic(fmt.Sprintf("route %s %s has no wildcard to dispatch by", method, path))
+	}
+
+	param := segments[wildcard][1:]
+
+	handlers := make(map[string]Handler, len(static))
+	for value, handler := range static {
+		staticSegments := append([]string(nil), segments...)
+		staticSegments[wildcard] = value
+
+		handlers[value] = m.wrap(method, strings.Join(staticSegments, "/"), handler)
+	}
+
+	next = m.wrap(method, path, next)
+
+	m.router.Handler(method, path, func(w http.ResponseWriter, req *http.Request) error {
+		if handler, ok := handlers[httprouter.GetParams(req.Context())[param]]; ok {
+			return handler(w, req)
+		}
+
+		return next(w, req)
+	})
+}
+
+func (m *Mux) wrap(method, path string, handler Handler) Handler {
+	for i := len(m.middleware) - 1; i >= 0; i-- {
+		handler = m.middleware[i](method, path, handler)
+	}
+
+	return handler
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Timeout sets deadline of request context to timeout.
+// Routes keyed by METHOD PATH such as "GET /api/v1/todo/export" have their own timeout,
+// e.g. transfers of request or response bodies that take longer than other operations.
+// Zero timeout leaves the route without deadline.
+func Timeout(timeout time.Duration, routes map[string]time.Duration) Middleware {
+	return func(method, path string, next Handler) Handler {
+		d, ok := routes[method+" "+path]
+		if !ok {
+			d = timeout
+		}
+
+		if d <= 0 {
+			return next
+		}
+
+		return func(w http.ResponseWriter, req *http.Request) error {
+			ctx, cancel := context.WithTimeout(req.Context(), d)
+			defer cancel()
+
+			return next(w, req.WithContext(ctx))
+		}
+	}
+}
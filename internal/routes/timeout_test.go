@@ -0,0 +1,64 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+)
+
+func TestTimeout(t *testing.T) {
+	middleware := routes.Timeout(time.Second, map[string]time.Duration{
+		"GET /api/v1/todo/export":  time.Hour,
+		"POST /api/v1/todo/import": 0,
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected time.Duration
+	}{
+		{name: "default", method: http.MethodGet, path: "/api/v1/todo/:id", expected: time.Second},
+		{name: "route", method: http.MethodGet, path: "/api/v1/todo/export", expected: time.Hour},
+		{name: "other method", method: http.MethodPost, path: "/api/v1/todo/export", expected: time.Second},
+		{name: "disabled", method: http.MethodPost, path: "/api/v1/todo/import"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				deadline time.Time
+				ok       bool
+			)
+
+			handler := middleware(tt.method, tt.path, func(w http.ResponseWriter, req *http.Request) error {
+				deadline, ok = req.Context().Deadline()
+				return nil
+			})
+
+			start := time.Now()
+			if err := handler(httptest.NewRecorder(), httptest.NewRequest(tt.method, "/", nil)); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.expected == 0 {
+				if ok {
+					t.Errorf("expected no deadline, got %s", deadline)
+				}
+
+				return
+			}
+
+			if !ok {
+				t.Fatal("expected deadline")
+			}
+
+			if actual := deadline.Sub(start); actual < tt.expected || actual > tt.expected+time.Second {
+				t.Errorf("expected deadline in %s, got %s", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"net/http"
+)
+
+// ResponseWriter records status and size of the response written by inner handlers.
+type ResponseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+// NewResponseWriter wraps w, if w is already a *ResponseWriter it's returned as is.
+func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
+	if rw, ok := w.(*ResponseWriter); ok {
+		return rw
+	}
+
+	return &ResponseWriter{ResponseWriter: w}
+}
+
+// Status returns status code written, zero if response was not started.
+func (rw *ResponseWriter) Status() int {
+	return rw.status
+}
+
+// Size returns number of body bytes written.
+func (rw *ResponseWriter) Size() int64 {
+	return rw.size
+}
+
+// Written reports whether response was started and can't be changed anymore.
+func (rw *ResponseWriter) Written() bool {
+	return rw.status != 0
+}
+
+func (rw *ResponseWriter) WriteHeader(status int) {
+	if rw.status == 0 {
+		rw.status = status
+	}
+
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *ResponseWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+
+	n, err := rw.ResponseWriter.Write(b)
+	rw.size += int64(n)
+
+	return n, err
+}
+
+func (rw *ResponseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		if rw.status == 0 {
+			rw.status = http.StatusOK
+		}
+
+		f.Flush()
+	}
+}
@@ -0,0 +1,113 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Certificate is a key pair reloaded when certificate or key file changes on disk.
+// Files are checked on handshake at most once per reload interval, so that no background goroutine is needed.
+type Certificate struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	stamp   stamp
+	checked time.Time
+}
+
+// stamp identifies version of certificate and key files.
+type stamp struct {
+	certModTime, keyModTime time.Time
+	certSize, keySize       int64
+}
+
+// LoadCertificate loads key pair from PEM encoded files.
+func LoadCertificate(certFile, keyFile string, interval time.Duration, logger *zap.Logger) (*Certificate, error) {
+	c := &Certificate{
+		certFile: certFile,
+		keyFile:  keyFile,
+		interval: interval,
+		logger:   logger,
+	}
+
+	s, err := c.stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.load(s); err != nil {
+		return nil, err
+	}
+
+	c.checked = time.Now()
+
+	return c, nil
+}
+
+// GetCertificate returns current certificate, it matches tls.Config GetCertificate.
+// Failed reload is logged and the previous certificate is served, so that a partially written rotation doesn't break handshakes.
+func (c *Certificate) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.checked) < c.interval {
+		return c.cert, nil
+	}
+
+	c.checked = now
+
+	s, err := c.stat()
+	switch {
+	case err != nil:
+		c.logger.Warn("certificate reload", zap.Error(err))
+	case s != c.stamp:
+		if err := c.load(s); err != nil {
+			c.logger.Warn("certificate reload", zap.Error(err))
+		} else {
+			c.logger.Info("certificate reloaded", zap.String("cert_file", c.certFile))
+		}
+	}
+
+	return c.cert, nil
+}
+
+func (c *Certificate) load(s stamp) error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	c.cert = &cert
+	c.stamp = s
+
+	return nil
+}
+
+func (c *Certificate) stat() (stamp, error) {
+	certInfo, err := os.Stat(c.certFile)
+	if err != nil {
+		return stamp{}, fmt.Errorf("failed to stat certificate: %w", err)
+	}
+
+	keyInfo, err := os.Stat(c.keyFile)
+	if err != nil {
+		return stamp{}, fmt.Errorf("failed to stat key: %w", err)
+	}
+
+	return stamp{
+		certModTime: certInfo.ModTime(),
+		keyModTime:  keyInfo.ModTime(),
+		certSize:    certInfo.Size(),
+		keySize:     keyInfo.Size(),
+	}, nil
+}
@@ -0,0 +1,89 @@
+// Package tlsutil builds TLS configuration of servers from PEM encoded files.
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Opt func(*options)
+
+type options struct {
+	clientCAFile   string
+	reloadInterval time.Duration
+	logger         *zap.Logger
+}
+
+// ClientCAs requires clients to present certificate signed by CA from the bundle, empty file disables client authentication.
+func ClientCAs(file string) Opt {
+	return func(o *options) {
+		o.clientCAFile = file
+	}
+}
+
+// ReloadInterval sets minimum interval of checking certificate files for changes.
+func ReloadInterval(interval time.Duration) Opt {
+	return func(o *options) {
+		o.reloadInterval = interval
+	}
+}
+
+func Logger(logger *zap.Logger) Opt {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// NewServerConfig creates server TLS configuration serving HTTP/2 and HTTP/1.1 with certificate reloaded from disk.
+func NewServerConfig(certFile, keyFile string, opts ...Opt) (*tls.Config, error) {
+	o := options{
+		reloadInterval: 10 * time.Second,
+		logger:         zap.NewNop(),
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cert, err := LoadCertificate(certFile, keyFile, o.reloadInterval, o.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: cert.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+
+	if o.clientCAFile != "" {
+		pool, err := loadCertPool(o.clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("failed to parse CA bundle %q: no certificates found", file)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtx"
+)
+
+// Interceptor starts client span for each query named after sqlc query.
+// System identifies database such as semconv.DBSystemPostgreSQL or semconv.DBSystemSqlite.
+func Interceptor(provider trace.TracerProvider, system attribute.KeyValue) dbtx.Interceptor {
+	tracer := provider.Tracer(instrumentationName)
+
+	return func(ctx context.Context, query dbtx.Query, next dbtx.Next) (dbtx.Result, error) {
+		ctx, span := tracer.Start(ctx, query.Name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				system,
+				semconv.DBOperationKey.String(query.Op),
+				semconv.DBStatementKey.String(query.SQL),
+			),
+		)
+		defer span.End()
+
+		res, err := next(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) && !errors.Is(err, sql.ErrNoRows) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		if res.Rows >= 0 {
+			span.SetAttributes(attribute.Int64("db.rows", res.Rows))
+		}
+
+		return res, err
+	}
+}
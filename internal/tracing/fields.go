@@ -0,0 +1,19 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// LogFields returns trace context in the format used by zapdriver so that logs are correlated with traces.
+func LogFields(sc trace.SpanContext) []zap.Field {
+	if !sc.IsValid() {
+		return nil
+	}
+
+	return []zap.Field{
+		zap.String("logging.googleapis.com/trace", sc.TraceID().String()),
+		zap.String("logging.googleapis.com/spanId", sc.SpanID().String()),
+		zap.Bool("logging.googleapis.com/trace_sampled", sc.IsSampled()),
+	}
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+)
+
+// Handler starts server span for each request continuing trace propagated by the client.
+// It wraps the router so that span and trace ID in logging fields are present for router level middleware such as request logging.
+// Span is named after method until Middleware renames it after route template.
+func Handler(provider trace.TracerProvider, propagator propagation.TextMapPropagator, next http.Handler) http.Handler {
+	tracer := provider.Tracer(instrumentationName)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := propagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+		ctx, span := tracer.Start(ctx, "HTTP "+req.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest("", "", req)...),
+			trace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", req)...),
+		)
+		defer span.End()
+
+		ctx = logging.WithFields(ctx, LogFields(span.SpanContext())...)
+
+		rw := routes.NewResponseWriter(w)
+		next.ServeHTTP(rw, req.WithContext(ctx))
+
+		status := rw.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, fmt.Sprintf("status %d", status))
+		}
+	})
+}
+
+// Middleware names span started by Handler after route template and records errors returned by handlers.
+func Middleware() routes.Middleware {
+	return func(method, path string, next routes.Handler) routes.Handler {
+		name := method + " " + path
+
+		return func(w http.ResponseWriter, req *http.Request) error {
+			span := trace.SpanFromContext(req.Context())
+			span.SetName(name)
+			span.SetAttributes(semconv.HTTPRouteKey.String(path))
+
+			err := next(w, req)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return err
+		}
+	}
+}
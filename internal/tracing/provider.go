@@ -0,0 +1,153 @@
+// Package tracing instruments HTTP handlers and database queries with OpenTelemetry spans.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+)
+
+const (
+	ExporterNone   = ""
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+
+	instrumentationName = "github.com/shaxbee/todo-app-skaffold/internal/tracing"
+
+	otlpTracesPath = "/v1/traces"
+)
+
+type config struct {
+	serviceName string
+	exporter    string
+	endpoint    string
+	sampleRatio float64
+	stdout      io.Writer
+}
+
+type Opt func(*config)
+
+func ServiceName(name string) Opt {
+	return func(c *config) {
+		c.serviceName = name
+	}
+}
+
+// Exporter is one of ExporterOTLP, ExporterStdout or ExporterNone, spans are not sampled without exporter.
+func Exporter(exporter string) Opt {
+	return func(c *config) {
+		c.exporter = exporter
+	}
+}
+
+// Endpoint is the base URL of OTLP HTTP collector such as http://localhost:4318.
+func Endpoint(endpoint string) Opt {
+	return func(c *config) {
+		c.endpoint = endpoint
+	}
+}
+
+// SampleRatio of traces started by this service, sampling decision of remote parent is respected.
+func SampleRatio(ratio float64) Opt {
+	return func(c *config) {
+		c.sampleRatio = ratio
+	}
+}
+
+// Stdout sets writer used by stdout exporter.
+func Stdout(w io.Writer) Opt {
+	return func(c *config) {
+		c.stdout = w
+	}
+}
+
+// NewProvider creates tracer provider exporting spans in batches.
+// Provider should be shut down to flush spans before exiting.
+func NewProvider(opts ...Opt) (*sdktrace.TracerProvider, error) {
+	c := config{
+		sampleRatio: 1,
+		stdout:      os.Stdout,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(c.serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	sampler := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.sampleRatio))
+
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+	}
+
+	switch c.exporter {
+	case ExporterNone:
+		// trace context is still propagated to downstream services
+		sampler = sdktrace.NeverSample()
+	case ExporterOTLP:
+		exporter, err := newOTLPExporter(c.endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(c.stdout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", c.exporter)
+	}
+
+	providerOpts = append(providerOpts, sdktrace.WithSampler(sampler))
+
+	return sdktrace.NewTracerProvider(providerOpts...), nil
+}
+
+// newOTLPExporter creates exporter sending spans to collector at base URL using OTLP over HTTP.
+func newOTLPExporter(endpoint string) (sdktrace.SpanExporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q", endpoint)
+	}
+
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(u.Host),
+		otlptracehttp.WithURLPath(strings.TrimSuffix(u.Path, "/") + otlpTracesPath),
+	}
+
+	switch u.Scheme {
+	case "http":
+		opts = append(opts, otlptracehttp.WithInsecure())
+	case "https":
+	default:
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: unsupported scheme %q", endpoint, u.Scheme)
+	}
+
+	// client connects on first export, context only bounds start of the exporter
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	return exporter, nil
+}
@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+)
+
+const bodyField = "body"
+
+// requestProblem converts request validation error to problem with field level errors.
+func requestProblem(err error) *problem.Problem {
+	var reqErr *openapi3filter.RequestError
+	if errors.As(err, &reqErr) && reqErr.RequestBody != nil {
+		var parseErr *openapi3filter.ParseError
+
+		switch {
+		case strings.HasPrefix(reqErr.Reason, "header Content-Type has unexpected value"):
+			return problem.UnsupportedMediaType(problem.Detail(reqErr.Reason), problem.Cause(err))
+		case errors.As(reqErr.Err, &parseErr):
+			return problem.MalformedRequest(problem.Detail("invalid request body"), problem.Cause(err))
+		}
+	}
+
+	return problem.Validation(problem.Fields(fieldErrors("", err)...), problem.Cause(err))
+}
+
+func fieldErrors(field string, err error) []problem.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var fieldErrs []problem.FieldError
+		for _, err := range e {
+			fieldErrs = append(fieldErrs, fieldErrors(field, err)...)
+		}
+
+		return fieldErrs
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			field = e.Parameter.Name
+		}
+
+		if e.Err == nil {
+			return []problem.FieldError{newFieldError(field, e.Reason)}
+		}
+
+		return fieldErrors(field, e.Err)
+	case *openapi3.SchemaError:
+		pointer := strings.Join(e.JSONPointer(), ".")
+
+		switch {
+		case field == "":
+			field = pointer
+		case pointer != "":
+			field += "." + pointer
+		}
+
+		return []problem.FieldError{newFieldError(field, schemaReason(e))}
+	case *openapi3filter.ParseError:
+		return []problem.FieldError{newFieldError(field, e.Error())}
+	default:
+		if errors.Is(err, openapi3filter.ErrInvalidRequired) {
+			return []problem.FieldError{newFieldError(field, "is required")}
+		}
+
+		return []problem.FieldError{newFieldError(field, err.Error())}
+	}
+}
+
+// schemaReason returns message of schema error, length errors use the same wording as handlers.
+func schemaReason(e *openapi3.SchemaError) string {
+	switch {
+	case e.SchemaField == "maxLength" && e.Schema != nil && e.Schema.MaxLength != nil:
+		return MaxLengthMessage(int(*e.Schema.MaxLength))
+	case e.SchemaField == "minLength" && e.Schema != nil:
+		return MinLengthMessage(int(e.Schema.MinLength))
+	case e.Reason == "":
+		return "doesn't match schema " + e.SchemaField
+	default:
+		return e.Reason
+	}
+}
+
+func newFieldError(field, message string) problem.FieldError {
+	if field == "" {
+		field = bodyField
+	}
+
+	return problem.FieldError{
+		Field:   field,
+		Message: message,
+	}
+}
@@ -0,0 +1,21 @@
+package validation
+
+import "fmt"
+
+// EmptyMessage is message of field error for empty string.
+const EmptyMessage = "should not be empty"
+
+// MaxLengthMessage returns message of field error for string exceeding maximum length.
+// Handlers checking lengths use the same messages as spec validation so that clients see one wording.
+func MaxLengthMessage(length int) string {
+	return fmt.Sprintf("should have maximum length of %d characters", length)
+}
+
+// MinLengthMessage returns message of field error for string shorter than minimum length.
+func MinLengthMessage(length int) string {
+	if length == 1 {
+		return EmptyMessage
+	}
+
+	return fmt.Sprintf("should have minimum length of %d characters", length)
+}
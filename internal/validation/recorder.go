@@ -0,0 +1,63 @@
+package validation
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// responseRecorder buffers JSON responses for validation, other responses are passed through.
+type responseRecorder struct {
+	http.ResponseWriter
+	status   int
+	buffered bool
+	body     bytes.Buffer
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{
+		ResponseWriter: w,
+	}
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	if rr.status != 0 {
+		return
+	}
+
+	rr.status = status
+	rr.buffered = isJSON(rr.Header().Get("Content-Type"))
+
+	if !rr.buffered {
+		rr.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	if rr.status == 0 {
+		rr.WriteHeader(http.StatusOK)
+	}
+
+	if rr.buffered {
+		return rr.body.Write(b)
+	}
+
+	return rr.ResponseWriter.Write(b)
+}
+
+func (rr *responseRecorder) Flush() {
+	if rr.buffered {
+		return
+	}
+
+	if f, ok := rr.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// flush writes buffered response.
+func (rr *responseRecorder) flush() error {
+	rr.ResponseWriter.WriteHeader(rr.status)
+	_, err := rr.body.WriteTo(rr.ResponseWriter)
+
+	return err
+}
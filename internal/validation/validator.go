@@ -0,0 +1,266 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/goes-funky/httprouter"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+)
+
+var errInvalidUUID = errors.New("should be a valid UUID")
+
+func init() {
+	// uuid format is not validated by default, use the same parser as handlers
+	openapi3.DefineStringFormatCallback("uuid", func(value string) error {
+		if _, err := uuid.Parse(value); err != nil {
+			return errInvalidUUID
+		}
+
+		return nil
+	})
+}
+
+// Validator validates requests and optionally responses against OpenAPI spec.
+type Validator struct {
+	spec              *openapi3.T
+	logger            *zap.Logger
+	validateResponses bool
+}
+
+type Opt func(*Validator)
+
+// Logger used to report invalid responses.
+func Logger(logger *zap.Logger) Opt {
+	return func(v *Validator) {
+		v.logger = logger
+	}
+}
+
+// ValidateResponses enables validation of JSON responses.
+// Responses are buffered, it's intended for development only.
+func ValidateResponses(enabled bool) Opt {
+	return func(v *Validator) {
+		v.validateResponses = enabled
+	}
+}
+
+// New loads and validates OpenAPI spec in YAML or JSON format.
+func New(ctx context.Context, data []byte, opts ...Opt) (*Validator, error) {
+	spec, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load spec: %w", err)
+	}
+
+	if err := spec.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("failed to validate spec: %w", err)
+	}
+
+	v := &Validator{
+		spec:   spec,
+		logger: zap.NewNop(),
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v, nil
+}
+
+// Middleware validates path, query parameters and bodies of requests to routes documented in the spec.
+// Invalid requests are rejected with validation problem before reaching the handler.
+func (v *Validator) Middleware() routes.Middleware {
+	return func(method, path string, next routes.Handler) routes.Handler {
+		route := v.route(method, specPath(path))
+		if route == nil && !v.hasStaticRoutes(method, path) {
+			v.logger.Warn("route is not documented in spec", zap.String("method", method), zap.String("route", path))
+			return next
+		}
+
+		return func(w http.ResponseWriter, req *http.Request) error {
+			route := route
+
+			// static route sharing position with route wildcard, such as /api/v1/todo/export
+			if static := v.route(method, req.URL.Path); static != nil {
+				route = static
+			}
+
+			// wildcard route only dispatching to static routes
+			if route == nil {
+				return next(w, req)
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams(req, route),
+				Route:      route,
+				Options: &openapi3filter.Options{
+					ExcludeRequestBody: !hasJSONBody(route.Operation),
+					MultiError:         true,
+				},
+			}
+
+			if err := openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+				return requestProblem(err)
+			}
+
+			if !v.validateResponses {
+				return next(w, req)
+			}
+
+			rw := newResponseRecorder(w)
+			err := next(rw, req)
+
+			if !rw.buffered {
+				return err
+			}
+
+			if err != nil {
+				// handler failed before writing response
+				if rw.status == 0 {
+					return err
+				}
+
+				logging.Logger(req.Context(), v.logger).Error("request failed after writing response", zap.Error(err))
+			}
+
+			resInput := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 rw.status,
+				Header:                 rw.Header(),
+				Options: &openapi3filter.Options{
+					IncludeResponseStatus: true,
+					MultiError:            true,
+				},
+			}
+			resInput.SetBodyBytes(rw.body.Bytes())
+
+			if err := openapi3filter.ValidateResponse(req.Context(), resInput); err != nil {
+				logging.Logger(req.Context(), v.logger).Error("response does not match spec",
+					zap.String("method", method),
+					zap.String("route", path),
+					zap.Int("status", rw.status),
+					zap.Error(err),
+				)
+
+				return problem.Internal(problem.Detail("response does not match spec"), problem.Cause(err))
+			}
+
+			return rw.flush()
+		}
+	}
+}
+
+func (v *Validator) route(method, path string) *routers.Route {
+	pathItem, ok := v.spec.Paths[path]
+	if !ok {
+		return nil
+	}
+
+	operation := pathItem.GetOperation(method)
+	if operation == nil {
+		return nil
+	}
+
+	return &routers.Route{
+		Spec:      v.spec,
+		Path:      path,
+		PathItem:  pathItem,
+		Method:    method,
+		Operation: operation,
+	}
+}
+
+// hasStaticRoutes reports whether spec documents static routes matched by wildcard route path.
+// Such wildcard routes dispatch to static routes, for example POST /api/v1/todo/:id serves /api/v1/todo/import.
+func (v *Validator) hasStaticRoutes(method, path string) bool {
+	segments := strings.Split(path, "/")
+
+	for specPath, pathItem := range v.spec.Paths {
+		if pathItem.GetOperation(method) == nil {
+			continue
+		}
+
+		specSegments := strings.Split(specPath, "/")
+		if len(specSegments) != len(segments) {
+			continue
+		}
+
+		match := true
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, ":") {
+				match = match && !strings.HasPrefix(specSegments[i], "{")
+			} else {
+				match = match && segment == specSegments[i]
+			}
+		}
+
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
+
+// specPath converts httprouter path such as /api/v1/todo/:id to OpenAPI path /api/v1/todo/{id}.
+func specPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func pathParams(req *http.Request, route *routers.Route) map[string]string {
+	params := httprouter.GetParams(req.Context())
+	res := make(map[string]string)
+
+	for _, parameters := range []openapi3.Parameters{route.PathItem.Parameters, route.Operation.Parameters} {
+		for _, param := range parameters {
+			if param.Value == nil || param.Value.In != openapi3.ParameterInPath {
+				continue
+			}
+
+			res[param.Value.Name] = params[param.Value.Name]
+		}
+	}
+
+	return res
+}
+
+// hasJSONBody reports whether operation accepts JSON request body.
+// Other bodies such as CSV imports are streamed by handlers and not buffered for validation.
+func hasJSONBody(operation *openapi3.Operation) bool {
+	if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return false
+	}
+
+	for contentType := range operation.RequestBody.Value.Content {
+		if isJSON(contentType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isJSON(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,224 @@
+package todo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/goes-funky/httprouter"
+
+	"github.com/shaxbee/todo-app-skaffold/api"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+)
+
+const (
+	// multipartOverhead is allowance for part headers and boundaries on top of maximum attachment size.
+	multipartOverhead = 64 << 10
+	// multipartMaxMemory is size of uploaded file kept in memory, larger files are buffered in temporary files.
+	multipartMaxMemory = 1 << 20
+	sniffLength        = 512
+)
+
+// uploadAttachment accepts file in multipart form.
+// Size is checked before the body is read, body is limited as content length is not known for chunked requests.
+func (s *Server) uploadAttachment(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	todoID, err := idParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mediaType != "multipart/form-data" {
+		return problem.UnsupportedMediaType(problem.Detailf("unsupported content type %q, expected multipart/form-data", mediaType))
+	}
+
+	maxSize := s.service.MaxAttachmentSize()
+	tooLarge := problem.PayloadTooLarge(problem.Detailf("attachment exceeds maximum size of %d bytes", maxSize))
+
+	if req.ContentLength > maxSize+multipartOverhead {
+		return tooLarge
+	}
+
+	body := &limitedBody{ReadCloser: req.Body, remaining: maxSize + multipartOverhead}
+	req.Body = body
+
+	if err := req.ParseMultipartForm(multipartMaxMemory); err != nil {
+		if body.exceeded {
+			return tooLarge
+		}
+
+		return problem.MalformedRequest(problem.Detail("invalid multipart form"), problem.Cause(err))
+	}
+	defer req.MultipartForm.RemoveAll()
+
+	files := req.MultipartForm.File["file"]
+	if len(files) == 0 {
+		return problem.Validation(problem.Field("file", "is required"))
+	}
+
+	fh := files[0]
+
+	f, err := fh.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer f.Close()
+
+	contentType, err := fileContentType(fh, f)
+	if err != nil {
+		return err
+	}
+
+	a, err := s.service.AddAttachment(ctx, todoID, fh.Filename, contentType, fh.Size, f)
+	if err != nil {
+		return err
+	}
+
+	return httprouter.JSONResponse(w, http.StatusCreated, attachmentResponse(a))
+}
+
+func (s *Server) listAttachments(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	todoID, err := idParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := s.service.ListAttachments(ctx, todoID)
+	if err != nil {
+		return err
+	}
+
+	res := make([]api.Attachment, len(attachments))
+	for i, a := range attachments {
+		res[i] = attachmentResponse(a)
+	}
+
+	return httprouter.JSONResponse(w, http.StatusOK, res)
+}
+
+// downloadAttachment serves contents with support for range and conditional requests.
+func (s *Server) downloadAttachment(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	todoID, err := idParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuidParam(ctx, "attachment_id")
+	if err != nil {
+		return err
+	}
+
+	a, r, err := s.service.OpenAttachment(ctx, todoID, id)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	header := w.Header()
+	header.Set("Content-Type", a.ContentType)
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": a.Filename,
+	}))
+	// contents of attachment never change, id identifies them
+	header.Set("ETag", `"`+a.ID.String()+`"`)
+	header.Set("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(w, req, "", a.CreatedAt, r)
+
+	return nil
+}
+
+func (s *Server) deleteAttachment(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	todoID, err := idParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuidParam(ctx, "attachment_id")
+	if err != nil {
+		return err
+	}
+
+	if err := s.service.DeleteAttachment(ctx, todoID, id); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// fileContentType returns content type of the file part, generic types are detected from contents.
+func fileContentType(fh *multipart.FileHeader, f multipart.File) (string, error) {
+	contentType := fh.Header.Get("Content-Type")
+	if contentType != "" && contentType != "application/octet-stream" {
+		return contentType, nil
+	}
+
+	buf := make([]byte, sniffLength)
+
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
+
+func attachmentResponse(a model.TodoAttachment) api.Attachment {
+	return api.Attachment{
+		Id:          a.ID,
+		TodoId:      a.TodoID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// limitedBody fails reads past the limit and records that it was exceeded.
+// Errors returned by reads are wrapped by multipart parser, the flag tells them apart from malformed forms.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (lb *limitedBody) Read(p []byte) (int, error) {
+	if lb.remaining <= 0 {
+		// probe whether body continues past the limit
+		var b [1]byte
+
+		n, err := lb.ReadCloser.Read(b[:])
+		if n > 0 {
+			lb.exceeded = true
+			return 0, errors.New("request body too large")
+		}
+
+		return 0, err
+	}
+
+	if int64(len(p)) > lb.remaining {
+		p = p[:lb.remaining]
+	}
+
+	n, err := lb.ReadCloser.Read(p)
+	lb.remaining -= int64(n)
+
+	return n, err
+}
@@ -0,0 +1,283 @@
+package todo
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goes-funky/httprouter"
+	"github.com/google/uuid"
+
+	"github.com/shaxbee/todo-app-skaffold/api"
+	"github.com/shaxbee/todo-app-skaffold/internal/markdown"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+)
+
+const (
+	commentFormatMarkdown = "markdown"
+	commentFormatHTML     = "html"
+)
+
+func (s *Server) createComment(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	todoID, err := idParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	var ccReq api.CreateCommentRequest
+	if err := decodeJSON(req, &ccReq); err != nil {
+		return err
+	}
+
+	c, err := s.service.AddComment(ctx, todoID, ccReq.Author, ccReq.Content)
+	if err != nil {
+		return err
+	}
+
+	return httprouter.JSONResponse(w, http.StatusCreated, commentResponse(c, false))
+}
+
+// listComments returns page of comments, cursor of the next page is opaque to clients.
+func (s *Server) listComments(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	todoID, err := idParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	html, err := commentFormat(req)
+	if err != nil {
+		return err
+	}
+
+	limit, err := pageLimit(req)
+	if err != nil {
+		return err
+	}
+
+	after, err := parseCommentCursor(req.URL.Query().Get("cursor"))
+	if err != nil {
+		return err
+	}
+
+	page, err := s.service.ListComments(ctx, todoID, after, limit)
+	if err != nil {
+		return err
+	}
+
+	res := api.CommentPage{
+		Comments: make([]api.Comment, len(page.Comments)),
+	}
+
+	for i, c := range page.Comments {
+		res.Comments[i] = commentResponse(c, html)
+	}
+
+	if !page.Next.IsZero() {
+		next := formatCommentCursor(page.Next)
+		res.NextCursor = &next
+	}
+
+	return httprouter.JSONResponse(w, http.StatusOK, res)
+}
+
+func (s *Server) getComment(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	todoID, id, err := commentParams(req)
+	if err != nil {
+		return err
+	}
+
+	html, err := commentFormat(req)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.service.GetComment(ctx, todoID, id)
+	if err != nil {
+		return err
+	}
+
+	return httprouter.JSONResponse(w, http.StatusOK, commentResponse(c, html))
+}
+
+func (s *Server) updateComment(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	todoID, id, err := commentParams(req)
+	if err != nil {
+		return err
+	}
+
+	var ucReq api.UpdateCommentRequest
+	if err := decodeJSON(req, &ucReq); err != nil {
+		return err
+	}
+
+	c, err := s.service.EditComment(ctx, todoID, id, ucReq.Author, ucReq.Content)
+	if err != nil {
+		return err
+	}
+
+	return httprouter.JSONResponse(w, http.StatusOK, commentResponse(c, false))
+}
+
+func (s *Server) deleteComment(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	todoID, id, err := commentParams(req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.service.DeleteComment(ctx, todoID, id); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+func (s *Server) listCommentRevisions(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	todoID, id, err := commentParams(req)
+	if err != nil {
+		return err
+	}
+
+	html, err := commentFormat(req)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := s.service.ListCommentRevisions(ctx, todoID, id)
+	if err != nil {
+		return err
+	}
+
+	res := make([]api.CommentRevision, len(revisions))
+	for i, r := range revisions {
+		res[i] = api.CommentRevision{
+			Version:     r.Version,
+			Author:      r.Author,
+			Content:     r.Content,
+			ContentHtml: renderContent(r.Content, html),
+			CreatedAt:   r.CreatedAt,
+		}
+	}
+
+	return httprouter.JSONResponse(w, http.StatusOK, res)
+}
+
+func commentParams(req *http.Request) (todoID, id uuid.UUID, err error) {
+	ctx := req.Context()
+
+	todoID, err = idParam(ctx)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	id, err = uuidParam(ctx, "comment_id")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return todoID, id, nil
+}
+
+// commentFormat reports whether content should be rendered to HTML.
+func commentFormat(req *http.Request) (bool, error) {
+	switch format := req.URL.Query().Get("format"); format {
+	case "", commentFormatMarkdown:
+		return false, nil
+	case commentFormatHTML:
+		return true, nil
+	default:
+		return false, problem.Validation(problem.Field("format", fmt.Sprintf("unsupported comment format %q", format)))
+	}
+}
+
+func pageLimit(req *http.Request) (int, error) {
+	raw := req.URL.Query().Get("limit")
+	if raw == "" {
+		return DefaultCommentPageSize, nil
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, problem.Validation(problem.Field("limit", "should be an integer"), problem.Cause(err))
+	}
+
+	return limit, nil
+}
+
+// formatCommentCursor encodes position of comment as URL safe string.
+func formatCommentCursor(c CommentCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + " " + c.ID.String()))
+}
+
+// parseCommentCursor decodes cursor returned by formatCommentCursor, empty cursor starts from the first comment.
+func parseCommentCursor(raw string) (CommentCursor, error) {
+	if raw == "" {
+		return CommentCursor{}, nil
+	}
+
+	invalid := problem.Validation(problem.Field("cursor", "should be next_cursor of the previous page"))
+
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return CommentCursor{}, invalid
+	}
+
+	parts := strings.SplitN(string(decoded), " ", 2)
+	if len(parts) != 2 {
+		return CommentCursor{}, invalid
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return CommentCursor{}, invalid
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return CommentCursor{}, invalid
+	}
+
+	return CommentCursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+func commentResponse(c model.TodoComment, html bool) api.Comment {
+	return api.Comment{
+		Id:          c.ID,
+		TodoId:      c.TodoID,
+		Author:      c.Author,
+		Content:     c.Content,
+		ContentHtml: renderContent(c.Content, html),
+		Version:     c.Version,
+		CreatedAt:   c.CreatedAt,
+		UpdatedBy:   c.UpdatedBy,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// renderContent renders markdown to sanitized HTML if requested.
+func renderContent(content string, html bool) *string {
+	if !html {
+		return nil
+	}
+
+	rendered := markdown.Render(content)
+
+	return &rendered
+}
@@ -0,0 +1,52 @@
+package todo
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dberr"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
+	"github.com/shaxbee/todo-app-skaffold/internal/validation"
+)
+
+// Constraints maps violations of todo and comment table constraints to problems of the fields they guard.
+var Constraints = dberr.New(
+	dberr.Constraint("todo_title_not_empty", "title", validation.EmptyMessage),
+	dberr.Constraint("todo_title_length", "title", validation.MaxLengthMessage(MaxLimits.TitleLength)),
+	dberr.Constraint("todo_content_length", "content", validation.MaxLengthMessage(MaxLimits.ContentLength)),
+	dberr.Constraint("todo_comment_author_not_empty", "author", validation.EmptyMessage),
+	dberr.Constraint("todo_comment_author_length", "author", validation.MaxLengthMessage(CommentAuthorLength)),
+	dberr.Constraint("todo_comment_content_not_empty", "content", validation.EmptyMessage),
+	dberr.Constraint("todo_comment_content_length", "content", validation.MaxLengthMessage(CommentContentLength)),
+)
+
+// handle maps domain errors returned by handler to problems.
+func handle(handler routes.Handler) routes.Handler {
+	return func(w http.ResponseWriter, req *http.Request) error {
+		return problemFrom(handler(w, req))
+	}
+}
+
+// problemFrom is the only place where domain errors are translated to HTTP.
+// Constraint violations are mapped by Constraints, other errors are returned as is.
+func problemFrom(err error) error {
+	var validationErr *ValidationError
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &validationErr):
+		return problem.Validation(problem.Fields(validationErr.Fields...), problem.Cause(err))
+	case errors.Is(err, ErrNotFound):
+		return problem.NotFound(problem.Detail(err.Error()))
+	case errors.Is(err, ErrAttachmentTooLarge):
+		return problem.PayloadTooLarge(problem.Detail(err.Error()))
+	case errors.Is(err, ErrUnsupportedContentType):
+		return problem.UnsupportedMediaType(problem.Detail(err.Error()))
+	case errors.Is(err, ErrConflict):
+		return problem.Conflict(problem.Detail("todo was modified concurrently, retry the request"), problem.Cause(err))
+	default:
+		return Constraints.Map(err)
+	}
+}
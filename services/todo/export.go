@@ -0,0 +1,169 @@
+package todo
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/shaxbee/todo-app-skaffold/api"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+)
+
+var csvHeader = []string{"id", "title", "content"}
+
+type todoWriter interface {
+	Write(t model.Todo) error
+	Flush() error
+}
+
+// export streams todos from the database cursor straight into the response.
+// It runs the same query as list, so it accepts the same filters.
+func (s *Server) export(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	format := req.URL.Query().Get("format")
+	if format == "" {
+		format = exportFormatCSV
+	}
+
+	var (
+		contentType string
+		tw          todoWriter
+	)
+
+	switch format {
+	case exportFormatCSV:
+		contentType = "text/csv; charset=utf-8"
+		tw = newCSVTodoWriter(w)
+	case exportFormatNDJSON:
+		contentType = "application/x-ndjson"
+		tw = newNDJSONTodoWriter(w)
+	default:
+		return problem.Validation(problem.Field("format", fmt.Sprintf("unsupported export format %q", format)))
+	}
+
+	rows, err := s.service.ListRows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": "todos." + format,
+	}))
+	w.WriteHeader(http.StatusOK)
+
+	for rows.Next() {
+		t, err := rows.Scan()
+		if err != nil {
+			return fmt.Errorf("failed to export todos: %w", err)
+		}
+
+		if err := tw.Write(t); err != nil {
+			return fmt.Errorf("failed to export todos: %w", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to export todos: %w", err)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to export todos: %w", err)
+	}
+
+	return nil
+}
+
+type csvTodoWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func newCSVTodoWriter(w io.Writer) *csvTodoWriter {
+	return &csvTodoWriter{
+		w: csv.NewWriter(w),
+	}
+}
+
+func (cw *csvTodoWriter) Write(t model.Todo) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	return cw.w.Write([]string{t.ID.String(), escapeCSVCell(t.Title), escapeCSVCell(t.Content)})
+}
+
+func (cw *csvTodoWriter) Flush() error {
+	// header is written even if there are no todos
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvTodoWriter) writeHeader() error {
+	if cw.header {
+		return nil
+	}
+
+	cw.header = true
+	return cw.w.Write(csvHeader)
+}
+
+// csvFormulaPrefixes start cells that spreadsheets evaluate as formulas, quote escapes cells that start with quote already.
+const csvFormulaPrefixes = "=+-@'"
+
+// escapeCSVCell prefixes cells that spreadsheets would evaluate as formulas with quote so that they are shown as text.
+// Import removes the prefix so that exported todos are imported unchanged.
+func escapeCSVCell(cell string) string {
+	if cell != "" && strings.IndexByte(csvFormulaPrefixes, cell[0]) != -1 {
+		return "'" + cell
+	}
+
+	return cell
+}
+
+// unescapeCSVCell reverses escapeCSVCell.
+func unescapeCSVCell(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.IndexByte(csvFormulaPrefixes, cell[1]) != -1 {
+		return cell[1:]
+	}
+
+	return cell
+}
+
+type ndjsonTodoWriter struct {
+	enc *json.Encoder
+}
+
+func newNDJSONTodoWriter(w io.Writer) *ndjsonTodoWriter {
+	return &ndjsonTodoWriter{
+		enc: json.NewEncoder(w),
+	}
+}
+
+func (nw *ndjsonTodoWriter) Write(t model.Todo) error {
+	return nw.enc.Encode(api.Todo{
+		Id:      t.ID,
+		Title:   t.Title,
+		Content: t.Content,
+	})
+}
+
+func (nw *ndjsonTodoWriter) Flush() error {
+	return nil
+}
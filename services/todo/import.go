@@ -0,0 +1,318 @@
+package todo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/goes-funky/httprouter"
+	"github.com/google/uuid"
+
+	"github.com/shaxbee/todo-app-skaffold/api"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+)
+
+const (
+	importBatchSize   = 1000
+	importMaxLineSize = 1 << 20
+)
+
+type todoReader interface {
+	// Read returns next todo and the line it starts at.
+	// Invalid rows are reported as *rowError, io.EOF is returned at the end of input.
+	Read() (api.CreateTodoRequest, int, error)
+}
+
+type rowError struct {
+	line    int
+	message string
+}
+
+func (e *rowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.line, e.message)
+}
+
+// importTodos validates every row with the same rules as create and inserts accepted rows in batches using COPY.
+// Rejected rows are reported back and do not abort the import, all accepted rows are inserted in a single transaction.
+func (s *Server) importTodos(w http.ResponseWriter, req *http.Request) error {
+	ctx := req.Context()
+
+	format, err := importFormat(req)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := boolQuery(req, "dry_run")
+	if err != nil {
+		return err
+	}
+
+	var tr todoReader
+
+	switch format {
+	case exportFormatCSV:
+		tr, err = newCSVTodoReader(req.Body)
+		if err != nil {
+			return err
+		}
+	case exportFormatNDJSON:
+		tr = newNDJSONTodoReader(req.Body)
+	default:
+		return problem.Validation(problem.Field("format", fmt.Sprintf("unsupported import format %q", format)))
+	}
+
+	report := api.ImportTodosResponse{
+		DryRun:   dryRun,
+		Accepted: []api.ImportedTodo{},
+		Rejected: []api.RejectedTodo{},
+	}
+
+	run := func(insert func(todos []model.Todo) error) error {
+		batch := make([]model.Todo, 0, importBatchSize)
+
+		for {
+			ctReq, line, err := tr.Read()
+
+			var rowErr *rowError
+
+			switch {
+			case errors.Is(err, io.EOF):
+				return insert(batch)
+			case errors.As(err, &rowErr):
+				report.Rejected = append(report.Rejected, api.RejectedTodo{
+					Line:    int32(rowErr.line),
+					Message: rowErr.message,
+				})
+
+				continue
+			case err != nil:
+				return err
+			}
+
+			if fieldErrs := s.service.validateCreateTodo(ctReq.Title, ctReq.Content); len(fieldErrs) > 0 {
+				report.Rejected = append(report.Rejected, api.RejectedTodo{
+					Line:    int32(line),
+					Message: joinFieldErrors(fieldErrs),
+				})
+
+				continue
+			}
+
+			id, err := uuid.NewRandom()
+			if err != nil {
+				return fmt.Errorf("failed to generate todo id: %w", err)
+			}
+
+			batch = append(batch, model.Todo{
+				ID:      id,
+				Title:   ctReq.Title,
+				Content: ctReq.Content,
+			})
+
+			report.Accepted = append(report.Accepted, api.ImportedTodo{
+				Line: int32(line),
+				Id:   id,
+			})
+
+			if len(batch) == importBatchSize {
+				if err := insert(batch); err != nil {
+					return err
+				}
+
+				batch = batch[:0]
+			}
+		}
+	}
+
+	if dryRun {
+		err = run(func([]model.Todo) error { return nil })
+	} else {
+		err = s.service.Import(ctx, run)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return httprouter.JSONResponse(w, http.StatusOK, report)
+}
+
+func importFormat(req *http.Request) (string, error) {
+	if format := req.URL.Query().Get("format"); format != "" {
+		return format, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "text/csv":
+		return exportFormatCSV, nil
+	case "application/x-ndjson":
+		return exportFormatNDJSON, nil
+	default:
+		return "", problem.UnsupportedMediaType(problem.Detailf("unsupported content type %q", mediaType))
+	}
+}
+
+func boolQuery(req *http.Request, name string) (bool, error) {
+	raw := req.URL.Query().Get(name)
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, problem.Validation(problem.Field(name, "should be a boolean"), problem.Cause(err))
+	}
+
+	return value, nil
+}
+
+type csvTodoReader struct {
+	r       *csv.Reader
+	title   int
+	content int
+	records int
+}
+
+func newCSVTodoReader(r io.Reader) (*csvTodoReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	switch {
+	case errors.Is(err, io.EOF):
+		return nil, problem.MalformedRequest(problem.Detail("missing CSV header"))
+	case err != nil:
+		return nil, csvError(err)
+	}
+
+	tr := &csvTodoReader{
+		r:       cr,
+		title:   -1,
+		content: -1,
+	}
+
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "title":
+			tr.title = i
+		case "content":
+			tr.content = i
+		}
+	}
+
+	if tr.title == -1 || tr.content == -1 {
+		return nil, problem.MalformedRequest(problem.Detail("CSV header should contain title and content columns"))
+	}
+
+	return tr, nil
+}
+
+func (cr *csvTodoReader) Read() (api.CreateTodoRequest, int, error) {
+	record, err := cr.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return api.CreateTodoRequest{}, 0, io.EOF
+		}
+
+		return api.CreateTodoRequest{}, 0, csvError(err)
+	}
+
+	cr.records++
+	line := recordLine(cr.r, cr.records)
+
+	if len(record) <= cr.title || len(record) <= cr.content {
+		return api.CreateTodoRequest{}, line, &rowError{
+			line:    line,
+			message: fmt.Sprintf("expected at least %d columns, got %d", maxInt(cr.title, cr.content)+1, len(record)),
+		}
+	}
+
+	return api.CreateTodoRequest{
+		Title:   unescapeCSVCell(record[cr.title]),
+		Content: unescapeCSVCell(record[cr.content]),
+	}, line, nil
+}
+
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return problem.MalformedRequest(problem.Detailf("malformed CSV at line %d", parseErr.Line), problem.Cause(err))
+	}
+
+	return fmt.Errorf("failed to read CSV: %w", err)
+}
+
+type ndjsonTodoReader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+func newNDJSONTodoReader(r io.Reader) *ndjsonTodoReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, importMaxLineSize)
+
+	return &ndjsonTodoReader{
+		s: s,
+	}
+}
+
+func (nr *ndjsonTodoReader) Read() (api.CreateTodoRequest, int, error) {
+	for nr.s.Scan() {
+		nr.line++
+
+		raw := bytes.TrimSpace(nr.s.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var ctReq api.CreateTodoRequest
+		if err := json.Unmarshal(raw, &ctReq); err != nil {
+			return ctReq, nr.line, &rowError{
+				line:    nr.line,
+				message: fmt.Sprintf("invalid JSON: %v", err),
+			}
+		}
+
+		return ctReq, nr.line, nil
+	}
+
+	err := nr.s.Err()
+	switch {
+	case err == nil:
+		return api.CreateTodoRequest{}, 0, io.EOF
+	case errors.Is(err, bufio.ErrTooLong):
+		return api.CreateTodoRequest{}, 0, problem.MalformedRequest(
+			problem.Detailf("line %d exceeds maximum length of %d bytes", nr.line+1, importMaxLineSize),
+		)
+	default:
+		return api.CreateTodoRequest{}, 0, fmt.Errorf("failed to read NDJSON: %w", err)
+	}
+}
+
+func joinFieldErrors(fieldErrs []problem.FieldError) string {
+	messages := make([]string, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		messages[i] = fe.String()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
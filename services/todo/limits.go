@@ -0,0 +1,47 @@
+package todo
+
+import (
+	"fmt"
+)
+
+// Limits of todo fields, lengths are counted in runes.
+type Limits struct {
+	TitleLength   int
+	ContentLength int
+}
+
+// MaxLimits are enforced by database constraints, configured limits can't exceed them.
+var MaxLimits = Limits{
+	TitleLength:   1000,
+	ContentLength: 65536,
+}
+
+// DefaultLimits are used by service unless configured otherwise.
+var DefaultLimits = Limits{
+	TitleLength:   20,
+	ContentLength: MaxLimits.ContentLength,
+}
+
+// Validate checks that limits are positive and within MaxLimits.
+func (l Limits) Validate() error {
+	switch {
+	case l.TitleLength < 1 || l.TitleLength > MaxLimits.TitleLength:
+		return fmt.Errorf("title length should be between 1 and %d", MaxLimits.TitleLength)
+	case l.ContentLength < 1 || l.ContentLength > MaxLimits.ContentLength:
+		return fmt.Errorf("content length should be between 1 and %d", MaxLimits.ContentLength)
+	default:
+		return nil
+	}
+}
+
+// Limits of comment fields enforced by database constraints, lengths are counted in runes.
+const (
+	CommentAuthorLength  = 100
+	CommentContentLength = 10000
+)
+
+// Sizes of comment pages.
+const (
+	DefaultCommentPageSize = 20
+	MaxCommentPageSize     = 100
+)
@@ -0,0 +1,433 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+)
+
+// MemoryStore keeps todos in memory in insertion order, it's safe for concurrent use.
+type MemoryStore struct {
+	mu          sync.RWMutex
+	todos       []model.Todo
+	index       map[uuid.UUID]int
+	attachments map[uuid.UUID][]model.TodoAttachment
+	comments    map[uuid.UUID][]model.TodoComment
+	revisions   map[uuid.UUID][]model.TodoCommentRevision
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		index:       make(map[uuid.UUID]int),
+		attachments: make(map[uuid.UUID][]model.TodoAttachment),
+		comments:    make(map[uuid.UUID][]model.TodoComment),
+		revisions:   make(map[uuid.UUID][]model.TodoCommentRevision),
+	}
+}
+
+func (s *MemoryStore) Create(ctx context.Context, t model.Todo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.insert(t)
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id uuid.UUID) (model.Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i, ok := s.index[id]
+	if !ok {
+		return model.Todo{}, notFound(id)
+	}
+
+	return s.todos[i], nil
+}
+
+func (s *MemoryStore) List(ctx context.Context) ([]model.Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.todos) == 0 {
+		return nil, nil
+	}
+
+	todos := make([]model.Todo, len(s.todos))
+	copy(todos, s.todos)
+
+	return todos, nil
+}
+
+// ListRows returns cursor over snapshot of todos.
+func (s *MemoryStore) ListRows(ctx context.Context) (Rows, error) {
+	todos, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &memoryRows{todos: todos, pos: -1}, nil
+}
+
+// Import stages inserted todos and stores them at once if fn succeeds, store is not locked while fn runs.
+func (s *MemoryStore) Import(ctx context.Context, fn func(insert func(todos []model.Todo) error) error) error {
+	var staged []model.Todo
+
+	err := fn(func(todos []model.Todo) error {
+		staged = append(staged, todos...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[uuid.UUID]bool, len(staged))
+	for _, t := range staged {
+		if _, ok := s.index[t.ID]; ok || seen[t.ID] {
+			return fmt.Errorf("failed to import todos: todo %q %w", t.ID, ErrConflict)
+		}
+
+		seen[t.ID] = true
+	}
+
+	for _, t := range staged {
+		_ = s.insert(t)
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := s.index[id]
+	if !ok {
+		return nil, notFound(id)
+	}
+
+	s.todos = append(s.todos[:i], s.todos[i+1:]...)
+	delete(s.index, id)
+
+	for j := i; j < len(s.todos); j++ {
+		s.index[s.todos[j].ID] = j
+	}
+
+	attachments := attachmentIDs(s.attachments[id])
+	delete(s.attachments, id)
+
+	for _, c := range s.comments[id] {
+		delete(s.revisions, c.ID)
+	}
+
+	delete(s.comments, id)
+
+	return attachments, nil
+}
+
+func (s *MemoryStore) DeleteAll(ctx context.Context) ([]uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var attachments []uuid.UUID
+	for _, t := range s.todos {
+		attachments = append(attachments, attachmentIDs(s.attachments[t.ID])...)
+	}
+
+	s.todos = nil
+	s.index = make(map[uuid.UUID]int)
+	s.attachments = make(map[uuid.UUID][]model.TodoAttachment)
+	s.comments = make(map[uuid.UUID][]model.TodoComment)
+	s.revisions = make(map[uuid.UUID][]model.TodoCommentRevision)
+
+	return attachments, nil
+}
+
+func (s *MemoryStore) CreateAttachment(ctx context.Context, a model.TodoAttachment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.index[a.TodoID]; !ok {
+		return notFound(a.TodoID)
+	}
+
+	for _, attachments := range s.attachments {
+		for _, existing := range attachments {
+			if existing.ID == a.ID {
+				return fmt.Errorf("failed to create attachment: attachment %q %w", a.ID, ErrConflict)
+			}
+		}
+	}
+
+	s.attachments[a.TodoID] = append(s.attachments[a.TodoID], a)
+
+	return nil
+}
+
+func (s *MemoryStore) GetAttachment(ctx context.Context, todoID, id uuid.UUID) (model.TodoAttachment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, a := range s.attachments[todoID] {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+
+	return model.TodoAttachment{}, attachmentNotFound(id)
+}
+
+func (s *MemoryStore) ListAttachments(ctx context.Context, todoID uuid.UUID) ([]model.TodoAttachment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	attachments := s.attachments[todoID]
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	res := make([]model.TodoAttachment, len(attachments))
+	copy(res, attachments)
+
+	return res, nil
+}
+
+func (s *MemoryStore) DeleteAttachment(ctx context.Context, todoID, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attachments := s.attachments[todoID]
+	for i, a := range attachments {
+		if a.ID == id {
+			s.attachments[todoID] = append(attachments[:i:i], attachments[i+1:]...)
+			return nil
+		}
+	}
+
+	return attachmentNotFound(id)
+}
+
+// CreateComment keeps comments of todo sorted by cursor.
+func (s *MemoryStore) CreateComment(ctx context.Context, c model.TodoComment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.index[c.TodoID]; !ok {
+		return notFound(c.TodoID)
+	}
+
+	if _, ok := s.revisions[c.ID]; ok {
+		return fmt.Errorf("failed to create comment: comment %q %w", c.ID, ErrConflict)
+	}
+
+	comments := append(s.comments[c.TodoID], c)
+	sort.Slice(comments, func(i, j int) bool {
+		return commentCursor(comments[i]).before(commentCursor(comments[j]))
+	})
+
+	s.comments[c.TodoID] = comments
+	s.revisions[c.ID] = []model.TodoCommentRevision{memoryCommentRevision(c)}
+
+	return nil
+}
+
+func (s *MemoryStore) GetComment(ctx context.Context, todoID, id uuid.UUID) (model.TodoComment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.commentIndex(todoID, id)
+	if i == -1 {
+		return model.TodoComment{}, commentNotFound(id)
+	}
+
+	return s.comments[todoID][i], nil
+}
+
+func (s *MemoryStore) ListComments(ctx context.Context, todoID uuid.UUID, after CommentCursor, limit int) ([]model.TodoComment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var res []model.TodoComment
+
+	for _, c := range s.comments[todoID] {
+		if len(res) == limit {
+			break
+		}
+
+		if after.before(commentCursor(c)) {
+			res = append(res, c)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *MemoryStore) UpdateComment(ctx context.Context, todoID, id uuid.UUID, author, content string, updatedAt time.Time) (model.TodoComment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.commentIndex(todoID, id)
+	if i == -1 {
+		return model.TodoComment{}, commentNotFound(id)
+	}
+
+	c := s.comments[todoID][i]
+	c.Content = content
+	c.Version++
+	c.UpdatedBy = author
+	c.UpdatedAt = updatedAt
+
+	s.comments[todoID][i] = c
+	s.revisions[id] = append(s.revisions[id], memoryCommentRevision(c))
+
+	return c, nil
+}
+
+func (s *MemoryStore) DeleteComment(ctx context.Context, todoID, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.commentIndex(todoID, id)
+	if i == -1 {
+		return commentNotFound(id)
+	}
+
+	comments := s.comments[todoID]
+	s.comments[todoID] = append(comments[:i:i], comments[i+1:]...)
+	delete(s.revisions, id)
+
+	return nil
+}
+
+func (s *MemoryStore) ListCommentRevisions(ctx context.Context, commentID uuid.UUID) ([]model.TodoCommentRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	revisions := s.revisions[commentID]
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+
+	res := make([]model.TodoCommentRevision, len(revisions))
+	copy(res, revisions)
+
+	return res, nil
+}
+
+// RunInTx calls fn with copy of the store that replaces its contents if fn succeeds, other operations wait until fn returns.
+func (s *MemoryStore) RunInTx(ctx context.Context, fn func(tx Store) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.clone()
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	s.todos, s.index, s.attachments, s.comments, s.revisions = tx.todos, tx.index, tx.attachments, tx.comments, tx.revisions
+
+	return nil
+}
+
+// clone expects the lock to be held, slices are copied as operations modify them in place.
+func (s *MemoryStore) clone() *MemoryStore {
+	c := NewMemoryStore()
+	c.todos = append([]model.Todo(nil), s.todos...)
+
+	for id, i := range s.index {
+		c.index[id] = i
+	}
+
+	for id, attachments := range s.attachments {
+		c.attachments[id] = append([]model.TodoAttachment(nil), attachments...)
+	}
+
+	for id, comments := range s.comments {
+		c.comments[id] = append([]model.TodoComment(nil), comments...)
+	}
+
+	for id, revisions := range s.revisions {
+		c.revisions[id] = append([]model.TodoCommentRevision(nil), revisions...)
+	}
+
+	return c
+}
+
+// commentIndex expects the lock to be held, -1 is returned if comment doesn't exist.
+func (s *MemoryStore) commentIndex(todoID, id uuid.UUID) int {
+	for i, c := range s.comments[todoID] {
+		if c.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// insert expects the lock to be held.
+func (s *MemoryStore) insert(t model.Todo) error {
+	if _, ok := s.index[t.ID]; ok {
+		return fmt.Errorf("failed to create todo: todo %q %w", t.ID, ErrConflict)
+	}
+
+	s.index[t.ID] = len(s.todos)
+	s.todos = append(s.todos, t)
+
+	return nil
+}
+
+func attachmentIDs(attachments []model.TodoAttachment) []uuid.UUID {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(attachments))
+	for i, a := range attachments {
+		ids[i] = a.ID
+	}
+
+	return ids
+}
+
+func memoryCommentRevision(c model.TodoComment) model.TodoCommentRevision {
+	return model.TodoCommentRevision{
+		CommentID: c.ID,
+		Version:   c.Version,
+		Author:    c.UpdatedBy,
+		Content:   c.Content,
+		CreatedAt: c.UpdatedAt,
+	}
+}
+
+type memoryRows struct {
+	todos []model.Todo
+	pos   int
+}
+
+func (r *memoryRows) Next() bool {
+	if r.pos+1 >= len(r.todos) {
+		return false
+	}
+
+	r.pos++
+
+	return true
+}
+
+func (r *memoryRows) Scan() (model.Todo, error) {
+	return r.todos[r.pos], nil
+}
+
+func (r *memoryRows) Err() error {
+	return nil
+}
+
+func (r *memoryRows) Close() {}
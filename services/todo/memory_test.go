@@ -0,0 +1,14 @@
+package todo_test
+
+import (
+	"testing"
+
+	"github.com/shaxbee/todo-app-skaffold/services/todo"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/storetest"
+)
+
+func TestMemoryStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) todo.Store {
+		return todo.NewMemoryStore()
+	})
+}
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE rate_limit (
+    key text PRIMARY KEY,
+    tokens double precision NOT NULL,
+    allowed boolean NOT NULL,
+    updated_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
+
+CREATE INDEX rate_limit_expires_at_idx ON rate_limit (expires_at);
+
+-- +goose Down
+DROP TABLE rate_limit;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- existing rows are not validated so that the migration can't fail on data written before the constraints
+ALTER TABLE todo
+    ADD CONSTRAINT todo_title_not_empty CHECK (title <> '') NOT VALID,
+    ADD CONSTRAINT todo_content_size CHECK (octet_length(content) <= 65536) NOT VALID;
+
+-- +goose Down
+ALTER TABLE todo
+    DROP CONSTRAINT todo_content_size,
+    DROP CONSTRAINT todo_title_not_empty;
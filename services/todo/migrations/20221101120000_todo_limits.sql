@@ -0,0 +1,17 @@
+-- +goose Up
+-- length limits are configured in the service, constraints only cap the maximum configurable limits
+ALTER TABLE todo
+    ALTER COLUMN title TYPE text,
+    DROP CONSTRAINT todo_content_size,
+    ADD CONSTRAINT todo_title_length CHECK (char_length(title) <= 1000) NOT VALID,
+    ADD CONSTRAINT todo_content_length CHECK (char_length(content) <= 65536) NOT VALID;
+
+-- +goose Down
+-- titles longer than the previous limit are truncated, content is not changed as its constraint is not validated
+UPDATE todo SET title = left(title, 20) WHERE char_length(title) > 20;
+
+ALTER TABLE todo
+    DROP CONSTRAINT todo_content_length,
+    DROP CONSTRAINT todo_title_length,
+    ADD CONSTRAINT todo_content_size CHECK (octet_length(content) <= 65536) NOT VALID,
+    ALTER COLUMN title TYPE varchar(20);
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE todo_attachment (
+    id uuid PRIMARY KEY,
+    todo_id uuid NOT NULL REFERENCES todo (id) ON DELETE CASCADE,
+    filename text NOT NULL,
+    content_type text NOT NULL,
+    size bigint NOT NULL,
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX todo_attachment_todo_id_idx ON todo_attachment (todo_id, created_at);
+
+-- +goose Down
+DROP TABLE todo_attachment;
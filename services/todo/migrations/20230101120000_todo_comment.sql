@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE todo_comment (
+    id uuid PRIMARY KEY,
+    todo_id uuid NOT NULL REFERENCES todo (id) ON DELETE CASCADE,
+    author text NOT NULL,
+    content text NOT NULL,
+    version integer NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_by text NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT todo_comment_author_not_empty CHECK (author <> ''),
+    CONSTRAINT todo_comment_author_length CHECK (char_length(author) <= 100),
+    CONSTRAINT todo_comment_content_not_empty CHECK (content <> ''),
+    CONSTRAINT todo_comment_content_length CHECK (char_length(content) <= 10000)
+);
+
+-- comments are paginated by (created_at, id)
+CREATE INDEX todo_comment_todo_id_idx ON todo_comment (todo_id, created_at, id);
+
+-- every version of comment including the current one, comment is edited by inserting next version
+CREATE TABLE todo_comment_revision (
+    comment_id uuid NOT NULL REFERENCES todo_comment (id) ON DELETE CASCADE,
+    version integer NOT NULL,
+    author text NOT NULL,
+    content text NOT NULL,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (comment_id, version)
+);
+
+-- +goose Down
+DROP TABLE todo_comment_revision;
+DROP TABLE todo_comment;
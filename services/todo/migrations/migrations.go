@@ -0,0 +1,18 @@
+// Package migrations embeds goose migrations of the todo schema.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// FS contains SQL migrations.
+//
+//go:embed *.sql
+var FS embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+// SQLite contains SQL migrations translated to the SQLite dialect, versions match migrations in FS.
+var SQLite, _ = fs.Sub(sqliteFS, "sqlite")
@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE todo (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL CHECK (length(title) <= 20),
+    content TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE todo;
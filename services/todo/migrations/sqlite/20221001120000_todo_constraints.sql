@@ -0,0 +1,25 @@
+-- +goose Up
+-- sqlite can't add constraints to existing table so it is rebuilt
+CREATE TABLE todo_new (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    content TEXT NOT NULL,
+    CONSTRAINT todo_title_length CHECK (length(title) <= 20),
+    CONSTRAINT todo_title_not_empty CHECK (title <> ''),
+    CONSTRAINT todo_content_size CHECK (length(CAST(content AS BLOB)) <= 65536)
+);
+
+INSERT INTO todo_new (id, title, content) SELECT id, title, content FROM todo ORDER BY rowid;
+DROP TABLE todo;
+ALTER TABLE todo_new RENAME TO todo;
+
+-- +goose Down
+CREATE TABLE todo_old (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL CHECK (length(title) <= 20),
+    content TEXT NOT NULL
+);
+
+INSERT INTO todo_old (id, title, content) SELECT id, title, content FROM todo ORDER BY rowid;
+DROP TABLE todo;
+ALTER TABLE todo_old RENAME TO todo;
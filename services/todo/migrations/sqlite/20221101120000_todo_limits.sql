@@ -0,0 +1,35 @@
+-- +goose Up
+-- length limits are configured in the service, constraints only cap the maximum configurable limits
+CREATE TABLE todo_new (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    content TEXT NOT NULL,
+    CONSTRAINT todo_title_length CHECK (length(title) <= 1000),
+    CONSTRAINT todo_title_not_empty CHECK (title <> ''),
+    CONSTRAINT todo_content_length CHECK (length(content) <= 65536)
+);
+
+INSERT INTO todo_new (id, title, content) SELECT id, title, content FROM todo ORDER BY rowid;
+DROP TABLE todo;
+ALTER TABLE todo_new RENAME TO todo;
+
+-- +goose Down
+CREATE TABLE todo_old (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    content TEXT NOT NULL,
+    CONSTRAINT todo_title_length CHECK (length(title) <= 20),
+    CONSTRAINT todo_title_not_empty CHECK (title <> ''),
+    CONSTRAINT todo_content_size CHECK (length(CAST(content AS BLOB)) <= 65536)
+);
+
+-- titles longer than the previous limit are truncated
+-- content exceeding the previous size limit is truncated to 16384 characters which fit in 65536 bytes of UTF-8
+INSERT INTO todo_old (id, title, content)
+SELECT
+    id,
+    substr(title, 1, 20),
+    CASE WHEN length(CAST(content AS BLOB)) > 65536 THEN substr(content, 1, 16384) ELSE content END
+FROM todo ORDER BY rowid;
+DROP TABLE todo;
+ALTER TABLE todo_old RENAME TO todo;
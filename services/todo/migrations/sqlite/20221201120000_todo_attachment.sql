@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE todo_attachment (
+    id TEXT PRIMARY KEY,
+    todo_id TEXT NOT NULL REFERENCES todo (id) ON DELETE CASCADE,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX todo_attachment_todo_id_idx ON todo_attachment (todo_id, created_at);
+
+-- +goose Down
+DROP TABLE todo_attachment;
@@ -3,11 +3,48 @@
 package model
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
+type RateLimit struct {
+	Key       string
+	Tokens    float64
+	UpdatedAt time.Time
+	ExpiresAt time.Time
+}
+
 type Todo struct {
 	ID      uuid.UUID
 	Title   string
 	Content string
 }
+
+type TodoAttachment struct {
+	ID          uuid.UUID
+	TodoID      uuid.UUID
+	Filename    string
+	ContentType string
+	Size        int64
+	CreatedAt   time.Time
+}
+
+type TodoComment struct {
+	ID        uuid.UUID
+	TodoID    uuid.UUID
+	Author    string
+	Content   string
+	Version   int32
+	CreatedAt time.Time
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+type TodoCommentRevision struct {
+	CommentID uuid.UUID
+	Version   int32
+	Author    string
+	Content   string
+	CreatedAt time.Time
+}
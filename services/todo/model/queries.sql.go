@@ -5,6 +5,7 @@ package model
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -20,7 +21,96 @@ type CreateParams struct {
 }
 
 func (q *Queries) Create(ctx context.Context, arg CreateParams) error {
-	_, err := q.db.ExecContext(ctx, create, arg.ID, arg.Title, arg.Content)
+	_, err := q.db.Exec(ctx, create, arg.ID, arg.Title, arg.Content)
+	return err
+}
+
+type CreateBatchParams struct {
+	ID      uuid.UUID
+	Title   string
+	Content string
+}
+
+const createAttachment = `-- name: CreateAttachment :exec
+INSERT INTO todo_attachment (id, todo_id, filename, content_type, size, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateAttachmentParams struct {
+	ID          uuid.UUID
+	TodoID      uuid.UUID
+	Filename    string
+	ContentType string
+	Size        int64
+	CreatedAt   time.Time
+}
+
+func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentParams) error {
+	_, err := q.db.Exec(ctx,
+		createAttachment,
+		arg.ID,
+		arg.TodoID,
+		arg.Filename,
+		arg.ContentType,
+		arg.Size,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createComment = `-- name: CreateComment :exec
+INSERT INTO todo_comment (id, todo_id, author, content, version, created_at, updated_by, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type CreateCommentParams struct {
+	ID        uuid.UUID
+	TodoID    uuid.UUID
+	Author    string
+	Content   string
+	Version   int32
+	CreatedAt time.Time
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) error {
+	_, err := q.db.Exec(ctx,
+		createComment,
+		arg.ID,
+		arg.TodoID,
+		arg.Author,
+		arg.Content,
+		arg.Version,
+		arg.CreatedAt,
+		arg.UpdatedBy,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const createCommentRevision = `-- name: CreateCommentRevision :exec
+INSERT INTO todo_comment_revision (comment_id, version, author, content, created_at)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateCommentRevisionParams struct {
+	CommentID uuid.UUID
+	Version   int32
+	Author    string
+	Content   string
+	CreatedAt time.Time
+}
+
+func (q *Queries) CreateCommentRevision(ctx context.Context, arg CreateCommentRevisionParams) error {
+	_, err := q.db.Exec(ctx,
+		createCommentRevision,
+		arg.CommentID,
+		arg.Version,
+		arg.Author,
+		arg.Content,
+		arg.CreatedAt,
+	)
 	return err
 }
 
@@ -29,11 +119,11 @@ DELETE FROM todo WHERE id=$1
 `
 
 func (q *Queries) Delete(ctx context.Context, id uuid.UUID) (int64, error) {
-	result, err := q.db.ExecContext(ctx, delete, id)
+	result, err := q.db.Exec(ctx, delete, id)
 	if err != nil {
 		return 0, err
 	}
-	return result.RowsAffected()
+	return result.RowsAffected(), nil
 }
 
 const deleteAll = `-- name: DeleteAll :exec
@@ -41,27 +131,157 @@ DELETE FROM todo
 `
 
 func (q *Queries) DeleteAll(ctx context.Context) error {
-	_, err := q.db.ExecContext(ctx, deleteAll)
+	_, err := q.db.Exec(ctx, deleteAll)
 	return err
 }
 
+const deleteAllAttachments = `-- name: DeleteAllAttachments :many
+DELETE FROM todo_attachment RETURNING id
+`
+
+func (q *Queries) DeleteAllAttachments(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, deleteAllAttachments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteAttachment = `-- name: DeleteAttachment :execrows
+DELETE FROM todo_attachment WHERE todo_id=$1 AND id=$2
+`
+
+type DeleteAttachmentParams struct {
+	TodoID uuid.UUID
+	ID     uuid.UUID
+}
+
+func (q *Queries) DeleteAttachment(ctx context.Context, arg DeleteAttachmentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAttachment, arg.TodoID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteComment = `-- name: DeleteComment :execrows
+DELETE FROM todo_comment WHERE todo_id=$1 AND id=$2
+`
+
+type DeleteCommentParams struct {
+	TodoID uuid.UUID
+	ID     uuid.UUID
+}
+
+func (q *Queries) DeleteComment(ctx context.Context, arg DeleteCommentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteComment, arg.TodoID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteTodoAttachments = `-- name: DeleteTodoAttachments :many
+DELETE FROM todo_attachment WHERE todo_id=$1 RETURNING id
+`
+
+func (q *Queries) DeleteTodoAttachments(ctx context.Context, todoID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, deleteTodoAttachments, todoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const get = `-- name: Get :one
 SELECT id, title, content FROM todo WHERE id=$1
 `
 
 func (q *Queries) Get(ctx context.Context, id uuid.UUID) (Todo, error) {
-	row := q.db.QueryRowContext(ctx, get, id)
+	row := q.db.QueryRow(ctx, get, id)
 	var i Todo
 	err := row.Scan(&i.ID, &i.Title, &i.Content)
 	return i, err
 }
 
+const getAttachment = `-- name: GetAttachment :one
+SELECT id, todo_id, filename, content_type, size, created_at FROM todo_attachment WHERE todo_id=$1 AND id=$2
+`
+
+type GetAttachmentParams struct {
+	TodoID uuid.UUID
+	ID     uuid.UUID
+}
+
+func (q *Queries) GetAttachment(ctx context.Context, arg GetAttachmentParams) (TodoAttachment, error) {
+	row := q.db.QueryRow(ctx, getAttachment, arg.TodoID, arg.ID)
+	var i TodoAttachment
+	err := row.Scan(
+		&i.ID,
+		&i.TodoID,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getComment = `-- name: GetComment :one
+SELECT id, todo_id, author, content, version, created_at, updated_by, updated_at FROM todo_comment WHERE todo_id=$1 AND id=$2
+`
+
+type GetCommentParams struct {
+	TodoID uuid.UUID
+	ID     uuid.UUID
+}
+
+func (q *Queries) GetComment(ctx context.Context, arg GetCommentParams) (TodoComment, error) {
+	row := q.db.QueryRow(ctx, getComment, arg.TodoID, arg.ID)
+	var i TodoComment
+	err := row.Scan(
+		&i.ID,
+		&i.TodoID,
+		&i.Author,
+		&i.Content,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const list = `-- name: List :many
 SELECT id, title, content FROM todo
 `
 
 func (q *Queries) List(ctx context.Context) ([]Todo, error) {
-	rows, err := q.db.QueryContext(ctx, list)
+	rows, err := q.db.Query(ctx, list)
 	if err != nil {
 		return nil, err
 	}
@@ -74,11 +294,155 @@ func (q *Queries) List(ctx context.Context) ([]Todo, error) {
 		}
 		items = append(items, i)
 	}
-	if err := rows.Close(); err != nil {
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAttachments = `-- name: ListAttachments :many
+SELECT id, todo_id, filename, content_type, size, created_at FROM todo_attachment WHERE todo_id=$1 ORDER BY created_at, id
+`
+
+func (q *Queries) ListAttachments(ctx context.Context, todoID uuid.UUID) ([]TodoAttachment, error) {
+	rows, err := q.db.Query(ctx, listAttachments, todoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TodoAttachment
+	for rows.Next() {
+		var i TodoAttachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.TodoID,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCommentRevisions = `-- name: ListCommentRevisions :many
+SELECT comment_id, version, author, content, created_at FROM todo_comment_revision WHERE comment_id=$1 ORDER BY version
+`
+
+func (q *Queries) ListCommentRevisions(ctx context.Context, commentID uuid.UUID) ([]TodoCommentRevision, error) {
+	rows, err := q.db.Query(ctx, listCommentRevisions, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TodoCommentRevision
+	for rows.Next() {
+		var i TodoCommentRevision
+		if err := rows.Scan(
+			&i.CommentID,
+			&i.Version,
+			&i.Author,
+			&i.Content,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	return items, nil
+}
+
+const listComments = `-- name: ListComments :many
+SELECT id, todo_id, author, content, version, created_at, updated_by, updated_at FROM todo_comment
+WHERE todo_id=$1 AND (created_at, id) > ($2, $3)
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListCommentsParams struct {
+	TodoID         uuid.UUID
+	AfterCreatedAt time.Time
+	AfterID        uuid.UUID
+	MaxComments    int32
+}
+
+func (q *Queries) ListComments(ctx context.Context, arg ListCommentsParams) ([]TodoComment, error) {
+	rows, err := q.db.Query(ctx,
+		listComments,
+		arg.TodoID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.MaxComments,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TodoComment
+	for rows.Next() {
+		var i TodoComment
+		if err := rows.Scan(
+			&i.ID,
+			&i.TodoID,
+			&i.Author,
+			&i.Content,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedBy,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 	return items, nil
 }
+
+const updateComment = `-- name: UpdateComment :one
+UPDATE todo_comment SET content=$1, version=version + 1, updated_by=$2, updated_at=$3
+WHERE todo_id=$4 AND id=$5
+RETURNING id, todo_id, author, content, version, created_at, updated_by, updated_at
+`
+
+type UpdateCommentParams struct {
+	Content   string
+	UpdatedBy string
+	UpdatedAt time.Time
+	TodoID    uuid.UUID
+	ID        uuid.UUID
+}
+
+func (q *Queries) UpdateComment(ctx context.Context, arg UpdateCommentParams) (TodoComment, error) {
+	row := q.db.QueryRow(ctx,
+		updateComment,
+		arg.Content,
+		arg.UpdatedBy,
+		arg.UpdatedAt,
+		arg.TodoID,
+		arg.ID,
+	)
+	var i TodoComment
+	err := row.Scan(
+		&i.ID,
+		&i.TodoID,
+		&i.Author,
+		&i.Content,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
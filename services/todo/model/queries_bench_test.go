@@ -0,0 +1,146 @@
+//go:build integration
+// +build integration
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtest"
+	"github.com/shaxbee/todo-app-skaffold/internal/dbutil"
+)
+
+const benchTodos = 100
+
+// BenchmarkGet compares sqlc pgx queries on pgxpool with the same query on database/sql adapter.
+func BenchmarkGet(b *testing.B) {
+	ctx := context.Background()
+	pool, db := setupBench(b)
+
+	ids := seedBench(b, pool)
+	queries := New(pool)
+
+	b.Run("pgxpool", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := queries.Get(ctx, ids[i%len(ids)]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("database/sql", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var t Todo
+			if err := db.QueryRowContext(ctx, get, ids[i%len(ids)]).Scan(&t.ID, &t.Title, &t.Content); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkList compares sqlc pgx queries on pgxpool with the same query on database/sql adapter.
+func BenchmarkList(b *testing.B) {
+	ctx := context.Background()
+	pool, db := setupBench(b)
+
+	seedBench(b, pool)
+	queries := New(pool)
+
+	b.Run("pgxpool", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			todos, err := queries.List(ctx)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if len(todos) != benchTodos {
+				b.Fatalf("expected %d todos, got %d", benchTodos, len(todos))
+			}
+		}
+	})
+
+	b.Run("database/sql", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			todos, err := listSQL(ctx, db)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if len(todos) != benchTodos {
+				b.Fatalf("expected %d todos, got %d", benchTodos, len(todos))
+			}
+		}
+	})
+}
+
+func setupBench(b *testing.B) (*pgxpool.Pool, *sql.DB) {
+	b.Helper()
+
+	ctx := context.Background()
+	dsn := dbtest.SetupPostgresDSN(b, dbtest.Migration("../migrations"))
+
+	pool, err := dbutil.OpenPool(ctx, dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	db := dbutil.SQL(pool)
+
+	b.Cleanup(func() {
+		db.Close()
+		pool.Close()
+	})
+
+	return pool, db
+}
+
+func seedBench(b *testing.B, pool *pgxpool.Pool) []uuid.UUID {
+	b.Helper()
+
+	ctx := context.Background()
+	queries := New(pool)
+
+	if err := queries.DeleteAll(ctx); err != nil {
+		b.Fatal(err)
+	}
+
+	ids := make([]uuid.UUID, benchTodos)
+	for i := range ids {
+		ids[i] = uuid.New()
+
+		if err := queries.Create(ctx, CreateParams{
+			ID:      ids[i],
+			Title:   fmt.Sprintf("todo %d", i),
+			Content: "benchmark",
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	return ids
+}
+
+func listSQL(ctx context.Context, db *sql.DB) ([]Todo, error) {
+	rows, err := db.QueryContext(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []Todo
+	for rows.Next() {
+		var i Todo
+		if err := rows.Scan(&i.ID, &i.Title, &i.Content); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+
+	return items, rows.Err()
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// TodoRows is a cursor over todos that scans one row at a time.
+type TodoRows struct {
+	rows pgx.Rows
+}
+
+// ListRows runs the List query and returns a cursor over the result instead of loading all todos into memory.
+// Caller is responsible for closing the cursor.
+func (q *Queries) ListRows(ctx context.Context) (*TodoRows, error) {
+	rows, err := q.db.Query(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoRows{rows: rows}, nil
+}
+
+// Next prepares the next todo for reading with Scan.
+func (r *TodoRows) Next() bool {
+	return r.rows.Next()
+}
+
+// Scan reads the current todo.
+func (r *TodoRows) Scan() (Todo, error) {
+	var i Todo
+	err := r.rows.Scan(&i.ID, &i.Title, &i.Content)
+	return i, err
+}
+
+// Err returns the error encountered during iteration.
+func (r *TodoRows) Err() error {
+	return r.rows.Err()
+}
+
+// Close closes the cursor, it's safe to call Close after the cursor was read to the end.
+func (r *TodoRows) Close() {
+	r.rows.Close()
+}
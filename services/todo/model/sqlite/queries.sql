@@ -0,0 +1,61 @@
+-- name: Get :one
+SELECT * FROM todo WHERE id=sqlc.arg(id);
+
+-- name: List :many
+SELECT * FROM todo;
+
+-- name: Create :exec
+INSERT INTO todo (id, title, content) VALUES (sqlc.arg(id), sqlc.arg(title), sqlc.arg(content));
+
+-- name: Delete :execrows
+DELETE FROM todo WHERE id=sqlc.arg(id);
+
+-- name: DeleteAll :exec
+DELETE FROM todo;
+
+-- name: CreateAttachment :exec
+INSERT INTO todo_attachment (id, todo_id, filename, content_type, size, created_at)
+VALUES (sqlc.arg(id), sqlc.arg(todo_id), sqlc.arg(filename), sqlc.arg(content_type), sqlc.arg(size), sqlc.arg(created_at));
+
+-- name: GetAttachment :one
+SELECT * FROM todo_attachment WHERE todo_id=sqlc.arg(todo_id) AND id=sqlc.arg(id);
+
+-- name: ListAttachments :many
+SELECT * FROM todo_attachment WHERE todo_id=sqlc.arg(todo_id) ORDER BY created_at, id;
+
+-- name: DeleteAttachment :execrows
+DELETE FROM todo_attachment WHERE todo_id=sqlc.arg(todo_id) AND id=sqlc.arg(id);
+
+-- name: DeleteTodoAttachments :many
+DELETE FROM todo_attachment WHERE todo_id=sqlc.arg(todo_id) RETURNING id;
+
+-- name: DeleteAllAttachments :many
+DELETE FROM todo_attachment RETURNING id;
+
+-- name: CreateComment :exec
+INSERT INTO todo_comment (id, todo_id, author, content, version, created_at, updated_by, updated_at)
+VALUES (sqlc.arg(id), sqlc.arg(todo_id), sqlc.arg(author), sqlc.arg(content), sqlc.arg(version), sqlc.arg(created_at), sqlc.arg(updated_by), sqlc.arg(updated_at));
+
+-- name: CreateCommentRevision :exec
+INSERT INTO todo_comment_revision (comment_id, version, author, content, created_at)
+VALUES (sqlc.arg(comment_id), sqlc.arg(version), sqlc.arg(author), sqlc.arg(content), sqlc.arg(created_at));
+
+-- name: GetComment :one
+SELECT * FROM todo_comment WHERE todo_id=sqlc.arg(todo_id) AND id=sqlc.arg(id);
+
+-- name: ListComments :many
+SELECT * FROM todo_comment
+WHERE todo_id=sqlc.arg(todo_id) AND (created_at, id) > (sqlc.arg(after_created_at), sqlc.arg(after_id))
+ORDER BY created_at, id
+LIMIT sqlc.arg(max_comments);
+
+-- name: UpdateComment :one
+UPDATE todo_comment SET content=sqlc.arg(content), version=version + 1, updated_by=sqlc.arg(updated_by), updated_at=sqlc.arg(updated_at)
+WHERE todo_id=sqlc.arg(todo_id) AND id=sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteComment :execrows
+DELETE FROM todo_comment WHERE todo_id=sqlc.arg(todo_id) AND id=sqlc.arg(id);
+
+-- name: ListCommentRevisions :many
+SELECT * FROM todo_comment_revision WHERE comment_id=sqlc.arg(comment_id) ORDER BY version;
@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+// TodoRows is a cursor over todos that scans one row at a time.
+type TodoRows struct {
+	rows *sql.Rows
+}
+
+// ListRows runs the List query and returns a cursor over the result instead of loading all todos into memory.
+// Caller is responsible for closing the cursor.
+func (q *Queries) ListRows(ctx context.Context) (*TodoRows, error) {
+	rows, err := q.db.QueryContext(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoRows{rows: rows}, nil
+}
+
+// Next prepares the next todo for reading with Scan.
+func (r *TodoRows) Next() bool {
+	return r.rows.Next()
+}
+
+// Scan reads the current todo.
+func (r *TodoRows) Scan() (Todo, error) {
+	var i Todo
+	err := r.rows.Scan(&i.ID, &i.Title, &i.Content)
+	return i, err
+}
+
+// Err returns the error encountered during iteration.
+func (r *TodoRows) Err() error {
+	return r.rows.Err()
+}
+
+// Close closes the cursor.
+func (r *TodoRows) Close() error {
+	return r.rows.Close()
+}
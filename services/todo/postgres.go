@@ -0,0 +1,436 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+)
+
+const (
+	defaultMaxAttempts = 3
+
+	serializationFailure = "40001"
+	foreignKeyViolation  = "23503"
+)
+
+// DB is DBTX that can start transactions, such as *pgxpool.Pool and *dbtx.DB.
+type DB interface {
+	model.DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// PostgresStore keeps todos in Postgres using sqlc queries.
+type PostgresStore struct {
+	db          DB
+	queries     *model.Queries
+	maxAttempts int
+	isolation   pgx.TxIsoLevel
+	// inTx is set for store passed to RunInTx, its operations run in the transaction
+	inTx bool
+}
+
+type PostgresOpt func(*PostgresStore)
+
+// MaxAttempts limits how many times transaction is run when it fails to serialize.
+func MaxAttempts(attempts int) PostgresOpt {
+	return func(s *PostgresStore) {
+		s.maxAttempts = attempts
+	}
+}
+
+// Isolation sets isolation level of transactions, serializable by default.
+func Isolation(level pgx.TxIsoLevel) PostgresOpt {
+	return func(s *PostgresStore) {
+		s.isolation = level
+	}
+}
+
+func NewPostgresStore(db DB, opts ...PostgresOpt) *PostgresStore {
+	s := &PostgresStore{
+		db:          db,
+		queries:     model.New(db),
+		maxAttempts: defaultMaxAttempts,
+		isolation:   pgx.Serializable,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// RunInTx runs fn in a transaction that is committed if fn succeeds.
+// Transaction is retried when it fails to serialize, fn should not have side effects outside of the database.
+func (s *PostgresStore) RunInTx(ctx context.Context, fn func(tx Store) error) error {
+	return s.runInTx(ctx, s.maxAttempts, func(q *model.Queries) error {
+		return fn(&PostgresStore{
+			db:          s.db,
+			queries:     q,
+			maxAttempts: s.maxAttempts,
+			isolation:   s.isolation,
+			inTx:        true,
+		})
+	})
+}
+
+func (s *PostgresStore) Create(ctx context.Context, t model.Todo) error {
+	err := s.queries.Create(ctx, model.CreateParams{
+		ID:      t.ID,
+		Title:   t.Title,
+		Content: t.Content,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create todo: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) Get(ctx context.Context, id uuid.UUID) (model.Todo, error) {
+	t, err := s.queries.Get(ctx, id)
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return model.Todo{}, notFound(id)
+	case err != nil:
+		return model.Todo{}, fmt.Errorf("failed to get todo: %w", err)
+	}
+
+	return t, nil
+}
+
+func (s *PostgresStore) List(ctx context.Context) ([]model.Todo, error) {
+	todos, err := s.queries.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+
+	return todos, nil
+}
+
+func (s *PostgresStore) ListRows(ctx context.Context) (Rows, error) {
+	rows, err := s.queries.ListRows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+
+	return rows, nil
+}
+
+// Import inserts todos using COPY in a single transaction.
+// Import is not retried as fn usually consumes a stream that can't be replayed.
+func (s *PostgresStore) Import(ctx context.Context, fn func(insert func(todos []model.Todo) error) error) error {
+	return s.runInTx(ctx, 1, func(q *model.Queries) error {
+		return fn(func(todos []model.Todo) error {
+			if len(todos) == 0 {
+				return nil
+			}
+
+			params := make([]model.CreateBatchParams, len(todos))
+			for i, t := range todos {
+				params[i] = model.CreateBatchParams{
+					ID:      t.ID,
+					Title:   t.Title,
+					Content: t.Content,
+				}
+			}
+
+			if _, err := q.CreateBatch(ctx, params); err != nil {
+				return fmt.Errorf("failed to import todos: %w", err)
+			}
+
+			return nil
+		})
+	})
+}
+
+// Delete removes attachments explicitly instead of relying on cascade to return their ids.
+func (s *PostgresStore) Delete(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	var attachments []uuid.UUID
+
+	err := s.runInTx(ctx, s.maxAttempts, func(q *model.Queries) error {
+		var err error
+
+		attachments, err = q.DeleteTodoAttachments(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete todo attachments: %w", err)
+		}
+
+		n, err := q.Delete(ctx, id)
+
+		switch {
+		case err != nil:
+			return fmt.Errorf("failed to delete todo: %w", err)
+		case n == 0:
+			return notFound(id)
+		default:
+			return nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (s *PostgresStore) DeleteAll(ctx context.Context) ([]uuid.UUID, error) {
+	var attachments []uuid.UUID
+
+	err := s.runInTx(ctx, s.maxAttempts, func(q *model.Queries) error {
+		var err error
+
+		attachments, err = q.DeleteAllAttachments(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete all attachments: %w", err)
+		}
+
+		if err := q.DeleteAll(ctx); err != nil {
+			return fmt.Errorf("failed to delete all todos: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (s *PostgresStore) CreateAttachment(ctx context.Context, a model.TodoAttachment) error {
+	err := s.queries.CreateAttachment(ctx, model.CreateAttachmentParams(a))
+
+	var pgErr *pgconn.PgError
+
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation:
+		return notFound(a.TodoID)
+	case err != nil:
+		return fmt.Errorf("failed to create attachment: %w", err)
+	default:
+		return nil
+	}
+}
+
+func (s *PostgresStore) GetAttachment(ctx context.Context, todoID, id uuid.UUID) (model.TodoAttachment, error) {
+	a, err := s.queries.GetAttachment(ctx, model.GetAttachmentParams{TodoID: todoID, ID: id})
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return model.TodoAttachment{}, attachmentNotFound(id)
+	case err != nil:
+		return model.TodoAttachment{}, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return a, nil
+}
+
+func (s *PostgresStore) ListAttachments(ctx context.Context, todoID uuid.UUID) ([]model.TodoAttachment, error) {
+	attachments, err := s.queries.ListAttachments(ctx, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (s *PostgresStore) DeleteAttachment(ctx context.Context, todoID, id uuid.UUID) error {
+	n, err := s.queries.DeleteAttachment(ctx, model.DeleteAttachmentParams{TodoID: todoID, ID: id})
+
+	switch {
+	case err != nil:
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	case n == 0:
+		return attachmentNotFound(id)
+	default:
+		return nil
+	}
+}
+
+// CreateComment inserts comment and its first revision in a transaction.
+func (s *PostgresStore) CreateComment(ctx context.Context, c model.TodoComment) error {
+	return s.runInTx(ctx, s.maxAttempts, func(q *model.Queries) error {
+		err := q.CreateComment(ctx, model.CreateCommentParams(c))
+
+		var pgErr *pgconn.PgError
+
+		switch {
+		case errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation:
+			return notFound(c.TodoID)
+		case err != nil:
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+
+		if err := q.CreateCommentRevision(ctx, commentRevision(c)); err != nil {
+			return fmt.Errorf("failed to create comment revision: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *PostgresStore) GetComment(ctx context.Context, todoID, id uuid.UUID) (model.TodoComment, error) {
+	c, err := s.queries.GetComment(ctx, model.GetCommentParams{TodoID: todoID, ID: id})
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return model.TodoComment{}, commentNotFound(id)
+	case err != nil:
+		return model.TodoComment{}, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return c, nil
+}
+
+func (s *PostgresStore) ListComments(ctx context.Context, todoID uuid.UUID, after CommentCursor, limit int) ([]model.TodoComment, error) {
+	comments, err := s.queries.ListComments(ctx, model.ListCommentsParams{
+		TodoID:         todoID,
+		AfterCreatedAt: after.CreatedAt,
+		AfterID:        after.ID,
+		MaxComments:    int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// UpdateComment updates comment and inserts the new version as revision in a transaction.
+func (s *PostgresStore) UpdateComment(ctx context.Context, todoID, id uuid.UUID, author, content string, updatedAt time.Time) (model.TodoComment, error) {
+	var c model.TodoComment
+
+	err := s.runInTx(ctx, s.maxAttempts, func(q *model.Queries) error {
+		var err error
+
+		c, err = q.UpdateComment(ctx, model.UpdateCommentParams{
+			Content:   content,
+			UpdatedBy: author,
+			UpdatedAt: updatedAt,
+			TodoID:    todoID,
+			ID:        id,
+		})
+
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return commentNotFound(id)
+		case err != nil:
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+
+		if err := q.CreateCommentRevision(ctx, commentRevision(c)); err != nil {
+			return fmt.Errorf("failed to create comment revision: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.TodoComment{}, err
+	}
+
+	return c, nil
+}
+
+func (s *PostgresStore) DeleteComment(ctx context.Context, todoID, id uuid.UUID) error {
+	n, err := s.queries.DeleteComment(ctx, model.DeleteCommentParams{TodoID: todoID, ID: id})
+
+	switch {
+	case err != nil:
+		return fmt.Errorf("failed to delete comment: %w", err)
+	case n == 0:
+		return commentNotFound(id)
+	default:
+		return nil
+	}
+}
+
+func (s *PostgresStore) ListCommentRevisions(ctx context.Context, commentID uuid.UUID) ([]model.TodoCommentRevision, error) {
+	revisions, err := s.queries.ListCommentRevisions(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// runInTx calls fn with queries of the transaction the store already runs in or starts a new one.
+func (s *PostgresStore) runInTx(ctx context.Context, attempts int, fn func(q *model.Queries) error) error {
+	if s.inTx {
+		return fn(s.queries)
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = 10 * time.Millisecond
+	bo.MaxInterval = 100 * time.Millisecond
+
+	var retries uint64
+	if attempts > 1 {
+		retries = uint64(attempts - 1)
+	}
+
+	err := backoff.Retry(func() error {
+		err := s.tx(ctx, fn)
+		if err != nil && !isSerializationFailure(err) {
+			return backoff.Permanent(err)
+		}
+
+		return err
+	}, backoff.WithContext(backoff.WithMaxRetries(bo, retries), ctx))
+
+	if isSerializationFailure(err) {
+		return fmt.Errorf("%w: transaction failed to serialize after %d attempts: %v", ErrConflict, attempts, err)
+	}
+
+	return err
+}
+
+func (s *PostgresStore) tx(ctx context.Context, fn func(q *model.Queries) error) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := tx.Exec(ctx, "SET TRANSACTION ISOLATION LEVEL "+string(s.isolation)); err != nil {
+		return fmt.Errorf("failed to set transaction isolation: %w", err)
+	}
+
+	if err := fn(s.queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// commentRevision returns current version of comment as revision.
+func commentRevision(c model.TodoComment) model.CreateCommentRevisionParams {
+	return model.CreateCommentRevisionParams{
+		CommentID: c.ID,
+		Version:   c.Version,
+		Author:    c.UpdatedBy,
+		Content:   c.Content,
+		CreatedAt: c.UpdatedAt,
+	}
+}
+
+func isSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == serializationFailure
+}
@@ -0,0 +1,35 @@
+//go:build integration
+// +build integration
+
+package todo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtest"
+	"github.com/shaxbee/todo-app-skaffold/internal/dbutil"
+	"github.com/shaxbee/todo-app-skaffold/services/todo"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/storetest"
+)
+
+func TestPostgresStore(t *testing.T) {
+	ctx := context.Background()
+
+	pool, err := dbutil.OpenPool(ctx, dbtest.SetupPostgresDSN(t, dbtest.Migration("migrations")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	storetest.Run(t, func(t *testing.T) todo.Store {
+		store := todo.NewPostgresStore(pool)
+
+		// tests share the database so that the container is started once
+		if _, err := store.DeleteAll(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		return store
+	})
+}
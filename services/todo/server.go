@@ -2,64 +2,79 @@ package todo
 
 import (
 	"context"
-	"database/sql"
-	"errors"
-	"fmt"
+	"encoding/json"
+	"mime"
 	"net/http"
 
 	"github.com/goes-funky/httprouter"
 	"github.com/google/uuid"
 
 	"github.com/shaxbee/todo-app-skaffold/api"
-	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/internal/routes"
 )
 
+// ReadQueries are sqlc queries that tolerate replication lag and can be served by read replica.
+var ReadQueries = []string{"Get", "List"}
+
 type Server struct {
-	queries *model.Queries
+	service *Service
 }
 
-func NewServer(db model.DBTX) *Server {
+func NewServer(service *Service) *Server {
 	return &Server{
-		queries: model.New(db),
+		service: service,
 	}
 }
 
-func (s *Server) RegisterRoutes(router *httprouter.Router) {
-	router.Handler(http.MethodPost, "/api/v1/todo", s.create)
-	router.Handler(http.MethodGet, "/api/v1/todo/:id", s.get)
-	router.Handler(http.MethodGet, "/api/v1/todo", s.list)
-	router.Handler(http.MethodDelete, "/api/v1/todo/:id", s.delete)
-	router.Handler(http.MethodDelete, "/api/v1/todo", s.deleteAll)
+// TransferRoutes stream request or response bodies, their duration depends on size of the body rather than on the operation.
+var TransferRoutes = []string{
+	http.MethodGet + " /api/v1/todo/export",
+	http.MethodPost + " /api/v1/todo/import",
+	http.MethodPost + " /api/v1/todo/:id/attachments",
+	http.MethodGet + " /api/v1/todo/:id/attachments/:attachment_id",
+}
+
+func (s *Server) RegisterRoutes(router *routes.Mux) {
+	router.Handler(http.MethodPost, "/api/v1/todo", handle(s.create))
+	// httprouter does not allow static segments next to wildcards followed by other segments
+	router.Dispatch(http.MethodPost, "/api/v1/todo/:id", map[string]routes.Handler{
+		"import": handle(s.importTodos),
+	}, handle(s.notFound))
+	router.Dispatch(http.MethodGet, "/api/v1/todo/:id", map[string]routes.Handler{
+		"export": handle(s.export),
+	}, handle(s.get))
+	router.Handler(http.MethodGet, "/api/v1/todo", handle(s.list))
+	router.Handler(http.MethodDelete, "/api/v1/todo/:id", handle(s.delete))
+	router.Handler(http.MethodDelete, "/api/v1/todo", handle(s.deleteAll))
+	router.Handler(http.MethodPost, "/api/v1/todo/:id/attachments", handle(s.uploadAttachment))
+	router.Handler(http.MethodGet, "/api/v1/todo/:id/attachments", handle(s.listAttachments))
+	router.Handler(http.MethodGet, "/api/v1/todo/:id/attachments/:attachment_id", handle(s.downloadAttachment))
+	router.Handler(http.MethodDelete, "/api/v1/todo/:id/attachments/:attachment_id", handle(s.deleteAttachment))
+	router.Handler(http.MethodPost, "/api/v1/todo/:id/comments", handle(s.createComment))
+	router.Handler(http.MethodGet, "/api/v1/todo/:id/comments", handle(s.listComments))
+	router.Handler(http.MethodGet, "/api/v1/todo/:id/comments/:comment_id", handle(s.getComment))
+	router.Handler(http.MethodPut, "/api/v1/todo/:id/comments/:comment_id", handle(s.updateComment))
+	router.Handler(http.MethodDelete, "/api/v1/todo/:id/comments/:comment_id", handle(s.deleteComment))
+	router.Handler(http.MethodGet, "/api/v1/todo/:id/comments/:comment_id/revisions", handle(s.listCommentRevisions))
+	router.Handler(http.MethodGet, "/api/v1/meta/limits", handle(s.limits))
 }
 
 func (s *Server) create(w http.ResponseWriter, req *http.Request) error {
 	ctx := req.Context()
 
 	var ctReq api.CreateTodoRequest
-	if err := httprouter.JSONRequest(req, &ctReq); err != nil {
+	if err := decodeJSON(req, &ctReq); err != nil {
 		return err
 	}
 
-	if len(ctReq.Title) > 20 {
-		return httprouter.NewError(http.StatusBadRequest, httprouter.Message("title should have maximum length of 20 characters"))
-	}
-
-	id, err := uuid.NewRandom()
+	t, err := s.service.Create(ctx, ctReq.Title, ctReq.Content)
 	if err != nil {
-		return fmt.Errorf("failed to generate todo id: %w", err)
-	}
-
-	err = s.queries.Create(ctx, model.CreateParams{
-		ID:      id,
-		Title:   ctReq.Title,
-		Content: ctReq.Content,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create todo: %w", err)
+		return err
 	}
 
 	return httprouter.JSONResponse(w, http.StatusCreated, api.CreateTodoResponse{
-		Id: id,
+		Id: t.ID,
 	})
 }
 
@@ -71,17 +86,9 @@ func (s *Server) get(w http.ResponseWriter, req *http.Request) error {
 		return err
 	}
 
-	t, err := s.queries.Get(ctx, id)
-
-	switch {
-	case errors.Is(err, sql.ErrNoRows):
-		return httprouter.NewError(
-			http.StatusNotFound,
-			httprouter.Messagef("todo %q not found", id),
-			httprouter.Operational(),
-		)
-	case err != nil:
-		return fmt.Errorf("failed to get todo: %w", err)
+	t, err := s.service.Get(ctx, id)
+	if err != nil {
+		return err
 	}
 
 	return httprouter.JSONResponse(w, http.StatusOK, api.Todo{
@@ -92,11 +99,9 @@ func (s *Server) get(w http.ResponseWriter, req *http.Request) error {
 }
 
 func (s *Server) list(w http.ResponseWriter, req *http.Request) error {
-	ctx := req.Context()
-
-	todos, err := s.queries.List(ctx)
+	todos, err := s.service.List(req.Context())
 	if err != nil {
-		return fmt.Errorf("failed to list todos: %w", err)
+		return err
 	}
 
 	resTodos := make([]api.Todo, len(todos))
@@ -119,25 +124,18 @@ func (s *Server) delete(w http.ResponseWriter, req *http.Request) error {
 		return err
 	}
 
-	n, err := s.queries.Delete(ctx, id)
-	switch {
-	case err != nil:
-		return fmt.Errorf("failed to delete todo: %w", err)
-	case n == 0:
-		return httprouter.NewError(
-			http.StatusNotFound,
-			httprouter.Messagef("todo %q not found", id),
-			httprouter.Operational(),
-		)
-	default:
-		w.WriteHeader(http.StatusNoContent)
-		return nil
+	if err := s.service.Delete(ctx, id); err != nil {
+		return err
 	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
 }
 
 func (s *Server) deleteAll(w http.ResponseWriter, req *http.Request) error {
-	if err := s.queries.DeleteAll(req.Context()); err != nil {
-		return fmt.Errorf("failed to delete all todos: %w", err)
+	if err := s.service.DeleteAll(req.Context()); err != nil {
+		return err
 	}
 
 	w.WriteHeader(http.StatusNoContent)
@@ -145,16 +143,45 @@ func (s *Server) deleteAll(w http.ResponseWriter, req *http.Request) error {
 	return nil
 }
 
+// notFound handles requests to wildcard routes registered only to dispatch static routes.
+func (s *Server) notFound(w http.ResponseWriter, req *http.Request) error {
+	return problem.NotFound(problem.Detailf("route %s %s not found", req.Method, req.URL.Path))
+}
+
+func (s *Server) limits(w http.ResponseWriter, req *http.Request) error {
+	limits := s.service.Limits()
+
+	return httprouter.JSONResponse(w, http.StatusOK, api.Limits{
+		TitleMaxLength:   int32(limits.TitleLength),
+		ContentMaxLength: int32(limits.ContentLength),
+	})
+}
+
+func decodeJSON(req *http.Request, v interface{}) error {
+	if mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mediaType != "" && mediaType != "application/json" {
+		return problem.UnsupportedMediaType(problem.Detailf("unsupported content type %q", mediaType))
+	}
+
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		return problem.MalformedRequest(problem.Detail("invalid JSON body"), problem.Cause(err))
+	}
+
+	return nil
+}
+
 func idParam(ctx context.Context) (uuid.UUID, error) {
+	return uuidParam(ctx, "id")
+}
+
+func uuidParam(ctx context.Context, name string) (uuid.UUID, error) {
 	params := httprouter.GetParams(ctx)
-	rawID := params["id"]
+	rawID := params[name]
 
 	id, err := uuid.Parse(rawID)
 	if err != nil {
-		return uuid.Nil, httprouter.NewError(
-			http.StatusBadRequest,
-			httprouter.Message("invalid id"),
-			httprouter.Cause(err),
+		return uuid.Nil, problem.Validation(
+			problem.Field(name, "should be a valid UUID"),
+			problem.Cause(err),
 		)
 	}
 
@@ -0,0 +1,509 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/blob"
+	"github.com/shaxbee/todo-app-skaffold/internal/logging"
+	"github.com/shaxbee/todo-app-skaffold/internal/problem"
+	"github.com/shaxbee/todo-app-skaffold/internal/validation"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+)
+
+// Domain errors are mapped to HTTP problems by the server.
+var (
+	ErrNotFound               = errors.New("not found")
+	ErrConflict               = errors.New("conflict")
+	ErrAttachmentTooLarge     = errors.New("attachment too large")
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+)
+
+const defaultMaxAttachmentSize = 10 << 20
+
+// ValidationError reports fields that violate domain rules.
+type ValidationError struct {
+	Fields []problem.FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid todo: " + joinFieldErrors(e.Fields)
+}
+
+// Service implements todo use cases independently of HTTP and storage.
+type Service struct {
+	store             Store
+	limits            Limits
+	blobs             BlobStore
+	maxAttachmentSize int64
+	attachmentTypes   []string
+	logger            *zap.Logger
+}
+
+type ServiceOpt func(*Service)
+
+// Blobs sets store of attachment contents, attachments can't be added without it.
+func Blobs(blobs BlobStore) ServiceOpt {
+	return func(s *Service) {
+		s.blobs = blobs
+	}
+}
+
+// AttachmentLimits restricts size of attachments in bytes and their content types.
+// Types can have wildcard subtype such as image/*, any type is accepted if types are empty.
+func AttachmentLimits(maxSize int64, contentTypes []string) ServiceOpt {
+	return func(s *Service) {
+		s.maxAttachmentSize = maxSize
+		s.attachmentTypes = contentTypes
+	}
+}
+
+// Logger reports blobs that failed to be cleaned up.
+func Logger(logger *zap.Logger) ServiceOpt {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// FieldLimits sets limits of todo fields, they should be within MaxLimits.
+func FieldLimits(limits Limits) ServiceOpt {
+	return func(s *Service) {
+		s.limits = limits
+	}
+}
+
+func NewService(store Store, opts ...ServiceOpt) *Service {
+	s := &Service{
+		store:             store,
+		limits:            DefaultLimits,
+		maxAttachmentSize: defaultMaxAttachmentSize,
+		logger:            zap.NewNop(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// RunInTx calls fn with store whose operations are applied at once if fn succeeds.
+// Transaction may be retried when it conflicts with concurrent one, fn should not have side effects outside of the store.
+func (s *Service) RunInTx(ctx context.Context, fn func(tx Store) error) error {
+	return s.store.RunInTx(ctx, fn)
+}
+
+// Limits returns active limits of todo fields.
+func (s *Service) Limits() Limits {
+	return s.limits
+}
+
+func (s *Service) Create(ctx context.Context, title, content string) (model.Todo, error) {
+	if fieldErrs := s.validateCreateTodo(title, content); len(fieldErrs) > 0 {
+		return model.Todo{}, &ValidationError{Fields: fieldErrs}
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return model.Todo{}, fmt.Errorf("failed to generate todo id: %w", err)
+	}
+
+	t := model.Todo{
+		ID:      id,
+		Title:   title,
+		Content: content,
+	}
+
+	if err := s.store.Create(ctx, t); err != nil {
+		return model.Todo{}, err
+	}
+
+	return t, nil
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (model.Todo, error) {
+	return s.store.Get(ctx, id)
+}
+
+func (s *Service) List(ctx context.Context) ([]model.Todo, error) {
+	return s.store.List(ctx)
+}
+
+// ListRows returns cursor over todos for streaming, caller is responsible for closing it.
+func (s *Service) ListRows(ctx context.Context) (Rows, error) {
+	return s.store.ListRows(ctx)
+}
+
+// Import calls fn with a function that inserts batches of todos, todos are stored only if fn succeeds.
+func (s *Service) Import(ctx context.Context, fn func(insert func(todos []model.Todo) error) error) error {
+	return s.store.Import(ctx, fn)
+}
+
+// Delete removes todo and contents of its attachments.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	attachments, err := s.store.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s.deleteBlobs(ctx, attachments...)
+
+	return nil
+}
+
+func (s *Service) DeleteAll(ctx context.Context) error {
+	attachments, err := s.store.DeleteAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.deleteBlobs(ctx, attachments...)
+
+	return nil
+}
+
+// MaxAttachmentSize returns maximum size of attachment in bytes.
+func (s *Service) MaxAttachmentSize() int64 {
+	return s.maxAttachmentSize
+}
+
+// AddAttachment stores contents read from r and attaches them to todo.
+// Contents are stored before metadata so that attachment is never visible without its contents.
+// Upload can't run in a transaction that may be retried, todo is checked before upload to avoid storing contents in vain
+// and creating metadata fails if todo was deleted meanwhile, the contents are removed then.
+func (s *Service) AddAttachment(ctx context.Context, todoID uuid.UUID, filename, contentType string, size int64, r io.Reader) (model.TodoAttachment, error) {
+	if s.blobs == nil {
+		return model.TodoAttachment{}, errors.New("attachment storage is not configured")
+	}
+
+	if size > s.maxAttachmentSize {
+		return model.TodoAttachment{}, fmt.Errorf("%w: size %d exceeds maximum of %d bytes", ErrAttachmentTooLarge, size, s.maxAttachmentSize)
+	}
+
+	if !contentTypeAllowed(contentType, s.attachmentTypes) {
+		return model.TodoAttachment{}, fmt.Errorf("%w %q, expected one of %s", ErrUnsupportedContentType, contentType, strings.Join(s.attachmentTypes, ", "))
+	}
+
+	if filename == "" {
+		return model.TodoAttachment{}, &ValidationError{Fields: []problem.FieldError{{Field: "file", Message: "should have a filename"}}}
+	}
+
+	if _, err := s.store.Get(ctx, todoID); err != nil {
+		return model.TodoAttachment{}, err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return model.TodoAttachment{}, fmt.Errorf("failed to generate attachment id: %w", err)
+	}
+
+	a := model.TodoAttachment{
+		ID:          id,
+		TodoID:      todoID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		// postgres stores microseconds
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+
+	if err := s.blobs.Put(ctx, id.String(), r, size, contentType); err != nil {
+		return model.TodoAttachment{}, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	if err := s.store.CreateAttachment(ctx, a); err != nil {
+		s.deleteBlobs(ctx, id)
+		return model.TodoAttachment{}, err
+	}
+
+	return a, nil
+}
+
+// ListAttachments returns attachments of todo, todo is checked in the same transaction so that
+// todo deleted concurrently is not found rather than listed without attachments.
+func (s *Service) ListAttachments(ctx context.Context, todoID uuid.UUID) ([]model.TodoAttachment, error) {
+	var attachments []model.TodoAttachment
+
+	err := s.RunInTx(ctx, func(tx Store) error {
+		if _, err := tx.Get(ctx, todoID); err != nil {
+			return err
+		}
+
+		var err error
+		attachments, err = tx.ListAttachments(ctx, todoID)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// OpenAttachment returns attachment with reader of its contents, caller is responsible for closing it.
+func (s *Service) OpenAttachment(ctx context.Context, todoID, id uuid.UUID) (model.TodoAttachment, io.ReadSeekCloser, error) {
+	a, err := s.store.GetAttachment(ctx, todoID, id)
+	if err != nil {
+		return model.TodoAttachment{}, nil, err
+	}
+
+	if s.blobs == nil {
+		return model.TodoAttachment{}, nil, errors.New("attachment storage is not configured")
+	}
+
+	r, err := s.blobs.Open(ctx, id.String())
+
+	switch {
+	case errors.Is(err, blob.ErrNotFound):
+		return model.TodoAttachment{}, nil, fmt.Errorf("%w: contents are missing", attachmentNotFound(id))
+	case err != nil:
+		return model.TodoAttachment{}, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+
+	return a, r, nil
+}
+
+func (s *Service) DeleteAttachment(ctx context.Context, todoID, id uuid.UUID) error {
+	if err := s.store.DeleteAttachment(ctx, todoID, id); err != nil {
+		return err
+	}
+
+	s.deleteBlobs(ctx, id)
+
+	return nil
+}
+
+// deleteBlobs removes contents of attachments whose metadata is already deleted.
+// Failures are logged rather than returned as the attachments are gone for clients, leftover blobs are unreachable.
+func (s *Service) deleteBlobs(ctx context.Context, ids ...uuid.UUID) {
+	if s.blobs == nil {
+		return
+	}
+
+	for _, id := range ids {
+		if err := s.blobs.Delete(ctx, id.String()); err != nil {
+			logging.Logger(ctx, s.logger).Warn("failed to delete attachment contents", zap.Stringer("attachment_id", id), zap.Error(err))
+		}
+	}
+}
+
+// contentTypeAllowed matches media type against allowed types, which can have wildcard subtype.
+func contentTypeAllowed(contentType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+		switch {
+		case pattern == "*/*" || pattern == mediaType:
+			return true
+		case strings.HasSuffix(pattern, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*")):
+			return true
+		}
+	}
+
+	return false
+}
+
+// CommentPage is a page of comments, Next is zero on the last page.
+type CommentPage struct {
+	Comments []model.TodoComment
+	Next     CommentCursor
+}
+
+// AddComment creates comment of todo as its first version.
+// Author is declared by the client and recorded as is, the API doesn't authenticate clients.
+func (s *Service) AddComment(ctx context.Context, todoID uuid.UUID, author, content string) (model.TodoComment, error) {
+	if fieldErrs := validateComment(author, content); len(fieldErrs) > 0 {
+		return model.TodoComment{}, &ValidationError{Fields: fieldErrs}
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return model.TodoComment{}, fmt.Errorf("failed to generate comment id: %w", err)
+	}
+
+	// postgres stores microseconds
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	c := model.TodoComment{
+		ID:        id,
+		TodoID:    todoID,
+		Author:    author,
+		Content:   content,
+		Version:   1,
+		CreatedAt: now,
+		UpdatedBy: author,
+		UpdatedAt: now,
+	}
+
+	if err := s.store.CreateComment(ctx, c); err != nil {
+		return model.TodoComment{}, err
+	}
+
+	return c, nil
+}
+
+func (s *Service) GetComment(ctx context.Context, todoID, id uuid.UUID) (model.TodoComment, error) {
+	return s.store.GetComment(ctx, todoID, id)
+}
+
+// ListComments returns up to limit comments of todo that follow the cursor in order of creation.
+// One more comment is fetched to tell whether there is a next page.
+func (s *Service) ListComments(ctx context.Context, todoID uuid.UUID, after CommentCursor, limit int) (CommentPage, error) {
+	if limit < 1 || limit > MaxCommentPageSize {
+		return CommentPage{}, &ValidationError{Fields: []problem.FieldError{{
+			Field:   "limit",
+			Message: fmt.Sprintf("should be between 1 and %d", MaxCommentPageSize),
+		}}}
+	}
+
+	var comments []model.TodoComment
+
+	err := s.RunInTx(ctx, func(tx Store) error {
+		if _, err := tx.Get(ctx, todoID); err != nil {
+			return err
+		}
+
+		var err error
+		comments, err = tx.ListComments(ctx, todoID, after, limit+1)
+
+		return err
+	})
+	if err != nil {
+		return CommentPage{}, err
+	}
+
+	page := CommentPage{Comments: comments}
+	if len(comments) > limit {
+		page.Comments = comments[:limit]
+		page.Next = commentCursor(comments[limit-1])
+	}
+
+	return page, nil
+}
+
+// EditComment replaces content of comment, author of the edit is recorded with the new version as updated by.
+// Author is declared by the client, edits by other authors are not rejected as the API doesn't authenticate clients.
+func (s *Service) EditComment(ctx context.Context, todoID, id uuid.UUID, author, content string) (model.TodoComment, error) {
+	if fieldErrs := validateComment(author, content); len(fieldErrs) > 0 {
+		return model.TodoComment{}, &ValidationError{Fields: fieldErrs}
+	}
+
+	return s.store.UpdateComment(ctx, todoID, id, author, content, time.Now().UTC().Truncate(time.Microsecond))
+}
+
+func (s *Service) DeleteComment(ctx context.Context, todoID, id uuid.UUID) error {
+	return s.store.DeleteComment(ctx, todoID, id)
+}
+
+// ListCommentRevisions returns edit history of comment, the last revision is the current version.
+func (s *Service) ListCommentRevisions(ctx context.Context, todoID, id uuid.UUID) ([]model.TodoCommentRevision, error) {
+	var revisions []model.TodoCommentRevision
+
+	err := s.RunInTx(ctx, func(tx Store) error {
+		if _, err := tx.GetComment(ctx, todoID, id); err != nil {
+			return err
+		}
+
+		var err error
+		revisions, err = tx.ListCommentRevisions(ctx, id)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func notFound(id uuid.UUID) error {
+	return fmt.Errorf("todo %q %w", id, ErrNotFound)
+}
+
+func attachmentNotFound(id uuid.UUID) error {
+	return fmt.Errorf("attachment %q %w", id, ErrNotFound)
+}
+
+func commentNotFound(id uuid.UUID) error {
+	return fmt.Errorf("comment %q %w", id, ErrNotFound)
+}
+
+// validateCreateTodo checks todo against the rules of CreateTodoRequest in the spec and configured limits.
+// Requests are validated by the spec middleware before reaching handlers, limits and imported rows are validated here.
+func (s *Service) validateCreateTodo(title, content string) []problem.FieldError {
+	var fieldErrs []problem.FieldError
+
+	switch {
+	case title == "":
+		fieldErrs = append(fieldErrs, problem.FieldError{
+			Field:   "title",
+			Message: validation.EmptyMessage,
+		})
+	case utf8.RuneCountInString(title) > s.limits.TitleLength:
+		fieldErrs = append(fieldErrs, problem.FieldError{
+			Field:   "title",
+			Message: validation.MaxLengthMessage(s.limits.TitleLength),
+		})
+	}
+
+	if utf8.RuneCountInString(content) > s.limits.ContentLength {
+		fieldErrs = append(fieldErrs, problem.FieldError{
+			Field:   "content",
+			Message: validation.MaxLengthMessage(s.limits.ContentLength),
+		})
+	}
+
+	return fieldErrs
+}
+
+// validateComment checks comment against the rules of CreateCommentRequest and UpdateCommentRequest in the spec.
+func validateComment(author, content string) []problem.FieldError {
+	var fieldErrs []problem.FieldError
+
+	switch {
+	case author == "":
+		fieldErrs = append(fieldErrs, problem.FieldError{
+			Field:   "author",
+			Message: validation.EmptyMessage,
+		})
+	case utf8.RuneCountInString(author) > CommentAuthorLength:
+		fieldErrs = append(fieldErrs, problem.FieldError{
+			Field:   "author",
+			Message: validation.MaxLengthMessage(CommentAuthorLength),
+		})
+	}
+
+	switch {
+	case content == "":
+		fieldErrs = append(fieldErrs, problem.FieldError{
+			Field:   "content",
+			Message: validation.EmptyMessage,
+		})
+	case utf8.RuneCountInString(content) > CommentContentLength:
+		fieldErrs = append(fieldErrs, problem.FieldError{
+			Field:   "content",
+			Message: validation.MaxLengthMessage(CommentContentLength),
+		})
+	}
+
+	return fieldErrs
+}
@@ -0,0 +1,436 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbtx"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model/sqlite"
+)
+
+// SQLiteStore keeps todos in SQLite database using sqlc queries generated for the SQLite dialect.
+type SQLiteStore struct {
+	db      *sql.DB
+	queries *sqlite.Queries
+	// interceptors are called around queries including ones run in transactions
+	interceptors []dbtx.Interceptor
+	// inTx is set for store passed to RunInTx, its operations run in the transaction
+	inTx bool
+}
+
+type SQLiteOpt func(*SQLiteStore)
+
+// Interceptors instruments queries, first interceptor is the outermost one.
+func Interceptors(interceptors ...dbtx.Interceptor) SQLiteOpt {
+	return func(s *SQLiteStore) {
+		s.interceptors = interceptors
+	}
+}
+
+func NewSQLiteStore(db *sql.DB, opts ...SQLiteOpt) *SQLiteStore {
+	s := &SQLiteStore{
+		db: db,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.queries = sqlite.New(dbtx.WrapSQL(db, s.interceptors...))
+
+	return s
+}
+
+func (s *SQLiteStore) Create(ctx context.Context, t model.Todo) error {
+	err := s.queries.Create(ctx, sqlite.CreateParams{
+		ID:      t.ID,
+		Title:   t.Title,
+		Content: t.Content,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create todo: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, id uuid.UUID) (model.Todo, error) {
+	t, err := s.queries.Get(ctx, id)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return model.Todo{}, notFound(id)
+	case err != nil:
+		return model.Todo{}, fmt.Errorf("failed to get todo: %w", err)
+	}
+
+	return model.Todo(t), nil
+}
+
+func (s *SQLiteStore) List(ctx context.Context) ([]model.Todo, error) {
+	todos, err := s.queries.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+
+	res := make([]model.Todo, len(todos))
+	for i, t := range todos {
+		res[i] = model.Todo(t)
+	}
+
+	return res, nil
+}
+
+func (s *SQLiteStore) ListRows(ctx context.Context) (Rows, error) {
+	rows, err := s.queries.ListRows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+
+	return sqliteRows{rows: rows}, nil
+}
+
+// Import inserts todos one by one in a single transaction as SQLite has no bulk copy.
+func (s *SQLiteStore) Import(ctx context.Context, fn func(insert func(todos []model.Todo) error) error) error {
+	return s.runInTx(ctx, func(q *sqlite.Queries) error {
+		return fn(func(todos []model.Todo) error {
+			for _, t := range todos {
+				err := q.Create(ctx, sqlite.CreateParams{
+					ID:      t.ID,
+					Title:   t.Title,
+					Content: t.Content,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to import todos: %w", err)
+				}
+			}
+
+			return nil
+		})
+	})
+}
+
+func (s *SQLiteStore) Delete(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	var attachments []uuid.UUID
+
+	err := s.runInTx(ctx, func(q *sqlite.Queries) error {
+		var err error
+
+		attachments, err = q.DeleteTodoAttachments(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete todo attachments: %w", err)
+		}
+
+		n, err := q.Delete(ctx, id)
+
+		switch {
+		case err != nil:
+			return fmt.Errorf("failed to delete todo: %w", err)
+		case n == 0:
+			return notFound(id)
+		default:
+			return nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (s *SQLiteStore) DeleteAll(ctx context.Context) ([]uuid.UUID, error) {
+	var attachments []uuid.UUID
+
+	err := s.runInTx(ctx, func(q *sqlite.Queries) error {
+		var err error
+
+		attachments, err = q.DeleteAllAttachments(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete all attachments: %w", err)
+		}
+
+		if err := q.DeleteAll(ctx); err != nil {
+			return fmt.Errorf("failed to delete all todos: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (s *SQLiteStore) CreateAttachment(ctx context.Context, a model.TodoAttachment) error {
+	err := s.queries.CreateAttachment(ctx, sqlite.CreateAttachmentParams(a))
+
+	var sqliteErr sqlite3.Error
+
+	switch {
+	case errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey:
+		return notFound(a.TodoID)
+	case err != nil:
+		return fmt.Errorf("failed to create attachment: %w", err)
+	default:
+		return nil
+	}
+}
+
+func (s *SQLiteStore) GetAttachment(ctx context.Context, todoID, id uuid.UUID) (model.TodoAttachment, error) {
+	a, err := s.queries.GetAttachment(ctx, sqlite.GetAttachmentParams{TodoID: todoID, ID: id})
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return model.TodoAttachment{}, attachmentNotFound(id)
+	case err != nil:
+		return model.TodoAttachment{}, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return model.TodoAttachment(a), nil
+}
+
+func (s *SQLiteStore) ListAttachments(ctx context.Context, todoID uuid.UUID) ([]model.TodoAttachment, error) {
+	attachments, err := s.queries.ListAttachments(ctx, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	res := make([]model.TodoAttachment, len(attachments))
+	for i, a := range attachments {
+		res[i] = model.TodoAttachment(a)
+	}
+
+	return res, nil
+}
+
+func (s *SQLiteStore) DeleteAttachment(ctx context.Context, todoID, id uuid.UUID) error {
+	n, err := s.queries.DeleteAttachment(ctx, sqlite.DeleteAttachmentParams{TodoID: todoID, ID: id})
+
+	switch {
+	case err != nil:
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	case n == 0:
+		return attachmentNotFound(id)
+	default:
+		return nil
+	}
+}
+
+// CreateComment inserts comment and its first revision in a transaction.
+func (s *SQLiteStore) CreateComment(ctx context.Context, c model.TodoComment) error {
+	return s.runInTx(ctx, func(q *sqlite.Queries) error {
+		err := q.CreateComment(ctx, sqlite.CreateCommentParams{
+			ID:        c.ID,
+			TodoID:    c.TodoID,
+			Author:    c.Author,
+			Content:   c.Content,
+			Version:   int64(c.Version),
+			CreatedAt: c.CreatedAt,
+			UpdatedBy: c.UpdatedBy,
+			UpdatedAt: c.UpdatedAt,
+		})
+
+		var sqliteErr sqlite3.Error
+
+		switch {
+		case errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey:
+			return notFound(c.TodoID)
+		case err != nil:
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+
+		if err := q.CreateCommentRevision(ctx, sqliteCommentRevision(c)); err != nil {
+			return fmt.Errorf("failed to create comment revision: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLiteStore) GetComment(ctx context.Context, todoID, id uuid.UUID) (model.TodoComment, error) {
+	c, err := s.queries.GetComment(ctx, sqlite.GetCommentParams{TodoID: todoID, ID: id})
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return model.TodoComment{}, commentNotFound(id)
+	case err != nil:
+		return model.TodoComment{}, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return commentFromSQLite(c), nil
+}
+
+func (s *SQLiteStore) ListComments(ctx context.Context, todoID uuid.UUID, after CommentCursor, limit int) ([]model.TodoComment, error) {
+	comments, err := s.queries.ListComments(ctx, sqlite.ListCommentsParams{
+		TodoID:         todoID,
+		AfterCreatedAt: after.CreatedAt,
+		AfterID:        after.ID,
+		MaxComments:    int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	res := make([]model.TodoComment, len(comments))
+	for i, c := range comments {
+		res[i] = commentFromSQLite(c)
+	}
+
+	return res, nil
+}
+
+// UpdateComment updates comment and inserts the new version as revision in a transaction.
+func (s *SQLiteStore) UpdateComment(ctx context.Context, todoID, id uuid.UUID, author, content string, updatedAt time.Time) (model.TodoComment, error) {
+	var c model.TodoComment
+
+	err := s.runInTx(ctx, func(q *sqlite.Queries) error {
+		updated, err := q.UpdateComment(ctx, sqlite.UpdateCommentParams{
+			Content:   content,
+			UpdatedBy: author,
+			UpdatedAt: updatedAt,
+			TodoID:    todoID,
+			ID:        id,
+		})
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return commentNotFound(id)
+		case err != nil:
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+
+		c = commentFromSQLite(updated)
+
+		if err := q.CreateCommentRevision(ctx, sqliteCommentRevision(c)); err != nil {
+			return fmt.Errorf("failed to create comment revision: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.TodoComment{}, err
+	}
+
+	return c, nil
+}
+
+func (s *SQLiteStore) DeleteComment(ctx context.Context, todoID, id uuid.UUID) error {
+	n, err := s.queries.DeleteComment(ctx, sqlite.DeleteCommentParams{TodoID: todoID, ID: id})
+
+	switch {
+	case err != nil:
+		return fmt.Errorf("failed to delete comment: %w", err)
+	case n == 0:
+		return commentNotFound(id)
+	default:
+		return nil
+	}
+}
+
+func (s *SQLiteStore) ListCommentRevisions(ctx context.Context, commentID uuid.UUID) ([]model.TodoCommentRevision, error) {
+	revisions, err := s.queries.ListCommentRevisions(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment revisions: %w", err)
+	}
+
+	res := make([]model.TodoCommentRevision, len(revisions))
+	for i, r := range revisions {
+		res[i] = model.TodoCommentRevision{
+			CommentID: r.CommentID,
+			Version:   int32(r.Version),
+			Author:    r.Author,
+			Content:   r.Content,
+			CreatedAt: r.CreatedAt,
+		}
+	}
+
+	return res, nil
+}
+
+// RunInTx runs fn in a transaction that is committed if fn succeeds.
+func (s *SQLiteStore) RunInTx(ctx context.Context, fn func(tx Store) error) error {
+	return s.runInTx(ctx, func(q *sqlite.Queries) error {
+		return fn(&SQLiteStore{db: s.db, queries: q, interceptors: s.interceptors, inTx: true})
+	})
+}
+
+// runInTx calls fn with queries of the transaction the store already runs in or starts a new one.
+func (s *SQLiteStore) runInTx(ctx context.Context, fn func(q *sqlite.Queries) error) error {
+	if s.inTx {
+		return fn(s.queries)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := fn(sqlite.New(dbtx.WrapSQL(tx, s.interceptors...))); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// commentFromSQLite converts version stored as INTEGER.
+func commentFromSQLite(c sqlite.TodoComment) model.TodoComment {
+	return model.TodoComment{
+		ID:        c.ID,
+		TodoID:    c.TodoID,
+		Author:    c.Author,
+		Content:   c.Content,
+		Version:   int32(c.Version),
+		CreatedAt: c.CreatedAt,
+		UpdatedBy: c.UpdatedBy,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// sqliteCommentRevision returns current version of comment as revision.
+func sqliteCommentRevision(c model.TodoComment) sqlite.CreateCommentRevisionParams {
+	return sqlite.CreateCommentRevisionParams{
+		CommentID: c.ID,
+		Version:   int64(c.Version),
+		Author:    c.UpdatedBy,
+		Content:   c.Content,
+		CreatedAt: c.UpdatedAt,
+	}
+}
+
+type sqliteRows struct {
+	rows *sqlite.TodoRows
+}
+
+func (r sqliteRows) Next() bool {
+	return r.rows.Next()
+}
+
+func (r sqliteRows) Scan() (model.Todo, error) {
+	t, err := r.rows.Scan()
+	return model.Todo(t), err
+}
+
+func (r sqliteRows) Err() error {
+	return r.rows.Err()
+}
+
+func (r sqliteRows) Close() {
+	_ = r.rows.Close()
+}
@@ -0,0 +1,74 @@
+package todo_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+
+	"github.com/shaxbee/todo-app-skaffold/internal/dbutil"
+	"github.com/shaxbee/todo-app-skaffold/services/todo"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/migrations"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/storetest"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) todo.Store {
+		return todo.NewSQLiteStore(openSQLite(t))
+	})
+}
+
+func TestSQLiteMigrateDown(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	title, content := strings.Repeat("a", 1000), strings.Repeat("ż", 65536)
+	if _, err := db.ExecContext(ctx, "INSERT INTO todo (id, title, content) VALUES ('a6dfe1f1-2d54-4a9b-9e0c-9b2bd0c0d3a4', ?, ?)", title, content); err != nil {
+		t.Fatal(err)
+	}
+
+	goose.SetBaseFS(migrations.SQLite)
+	defer goose.SetBaseFS(nil)
+
+	// version before length limits were made configurable
+	if err := goose.DownTo(db, ".", 20221001120000); err != nil {
+		t.Fatal(err)
+	}
+
+	var actualTitle, actualContent string
+	if err := db.QueryRowContext(ctx, "SELECT title, content FROM todo").Scan(&actualTitle, &actualContent); err != nil {
+		t.Fatal(err)
+	}
+
+	if actualTitle != title[:20] {
+		t.Errorf("expected title truncated to 20 characters, got %q", actualTitle)
+	}
+
+	if actualContent != strings.Repeat("ż", 16384) {
+		t.Errorf("expected content truncated to 16384 characters, got %d", len([]rune(actualContent)))
+	}
+}
+
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn, err := dbutil.SQLiteDSN("sqlite://" + filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := dbutil.Open(context.Background(), dbutil.SQLiteDriver, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := dbutil.Migrate(db, "sqlite3", migrations.SQLite); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
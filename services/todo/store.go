@@ -0,0 +1,93 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+)
+
+// Store persists todos, metadata of their attachments and comments, missing resources are reported with ErrNotFound.
+type Store interface {
+	Create(ctx context.Context, t model.Todo) error
+	Get(ctx context.Context, id uuid.UUID) (model.Todo, error)
+	List(ctx context.Context) ([]model.Todo, error)
+	// ListRows returns cursor over todos for streaming, caller is responsible for closing it.
+	ListRows(ctx context.Context) (Rows, error)
+	// Import calls fn with a function that inserts batches of todos, todos are stored only if fn succeeds.
+	Import(ctx context.Context, fn func(insert func(todos []model.Todo) error) error) error
+	// Delete removes todo with its attachments and returns ids of removed attachments.
+	Delete(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// DeleteAll removes all todos with their attachments and returns ids of removed attachments.
+	DeleteAll(ctx context.Context) ([]uuid.UUID, error)
+
+	// CreateAttachment stores attachment metadata, ErrNotFound is returned if todo doesn't exist.
+	CreateAttachment(ctx context.Context, a model.TodoAttachment) error
+	GetAttachment(ctx context.Context, todoID, id uuid.UUID) (model.TodoAttachment, error)
+	// ListAttachments returns attachments of todo in order of creation.
+	ListAttachments(ctx context.Context, todoID uuid.UUID) ([]model.TodoAttachment, error)
+	DeleteAttachment(ctx context.Context, todoID, id uuid.UUID) error
+
+	// CreateComment stores comment as its first revision, ErrNotFound is returned if todo doesn't exist.
+	CreateComment(ctx context.Context, c model.TodoComment) error
+	GetComment(ctx context.Context, todoID, id uuid.UUID) (model.TodoComment, error)
+	// ListComments returns up to limit comments of todo ordered by creation that follow the cursor.
+	ListComments(ctx context.Context, todoID uuid.UUID, after CommentCursor, limit int) ([]model.TodoComment, error)
+	// UpdateComment replaces content of comment and records it as the next revision.
+	UpdateComment(ctx context.Context, todoID, id uuid.UUID, author, content string, updatedAt time.Time) (model.TodoComment, error)
+	// DeleteComment removes comment with its revisions.
+	DeleteComment(ctx context.Context, todoID, id uuid.UUID) error
+	// ListCommentRevisions returns all versions of comment ordered from the first one.
+	ListCommentRevisions(ctx context.Context, commentID uuid.UUID) ([]model.TodoCommentRevision, error)
+
+	// RunInTx calls fn with store whose operations are applied at once if fn succeeds, the store should not be used after fn returns.
+	// Stores may call fn again if transaction conflicts with concurrent one, fn should not have side effects outside of the store.
+	RunInTx(ctx context.Context, fn func(tx Store) error) error
+}
+
+// CommentCursor is position of comment in order of creation, zero cursor precedes all comments.
+type CommentCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// IsZero reports whether cursor precedes all comments.
+func (c CommentCursor) IsZero() bool {
+	return c.CreatedAt.IsZero() && c.ID == uuid.Nil
+}
+
+// before reports whether c precedes other in order of creation.
+func (c CommentCursor) before(other CommentCursor) bool {
+	if !c.CreatedAt.Equal(other.CreatedAt) {
+		return c.CreatedAt.Before(other.CreatedAt)
+	}
+
+	return bytes.Compare(c.ID[:], other.ID[:]) < 0
+}
+
+// commentCursor returns position of comment.
+func commentCursor(c model.TodoComment) CommentCursor {
+	return CommentCursor{CreatedAt: c.CreatedAt, ID: c.ID}
+}
+
+// Rows is a cursor over todos, *model.TodoRows implements it.
+type Rows interface {
+	Next() bool
+	Scan() (model.Todo, error)
+	Err() error
+	Close()
+}
+
+// BlobStore keeps contents of attachments under attachment ids, blob.LocalStore and blob.S3Store implement it.
+type BlobStore interface {
+	// Put stores size bytes read from r under key.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open returns reader of blob that can seek to serve range requests, missing blob is reported with blob.ErrNotFound.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// Delete removes blob, missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
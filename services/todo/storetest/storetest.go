@@ -0,0 +1,610 @@
+// Package storetest is conformance test suite of todo.Store implementations.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+
+	"github.com/shaxbee/todo-app-skaffold/services/todo"
+	"github.com/shaxbee/todo-app-skaffold/services/todo/model"
+)
+
+// Run runs the suite, newStore should return an empty store for each test.
+func Run(t *testing.T, newStore func(t *testing.T) todo.Store) {
+	ctx := context.Background()
+
+	t.Run("create and get", func(t *testing.T) {
+		store := newStore(t)
+		expected := newTodo(0)
+
+		if err := store.Create(ctx, expected); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := store.Get(ctx, expected.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Error("expected created todo:", diff)
+		}
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		store := newStore(t)
+
+		if _, err := store.Get(ctx, uuid.New()); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected not found, got %v", err)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		store := newStore(t)
+		expected := createTodos(t, store, 3)
+
+		actual, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertTodos(t, expected, actual)
+	})
+
+	t.Run("list empty", func(t *testing.T) {
+		store := newStore(t)
+
+		actual, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(actual) != 0 {
+			t.Errorf("expected no todos, got %d", len(actual))
+		}
+	})
+
+	t.Run("list rows", func(t *testing.T) {
+		store := newStore(t)
+		expected := createTodos(t, store, 3)
+
+		rows, err := store.ListRows(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+
+		var actual []model.Todo
+		for rows.Next() {
+			item, err := rows.Scan()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual = append(actual, item)
+		}
+
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		assertTodos(t, expected, actual)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		store := newStore(t)
+		expected := []model.Todo{newTodo(0), newTodo(1), newTodo(2)}
+
+		err := store.Import(ctx, func(insert func(todos []model.Todo) error) error {
+			if err := insert(expected[:2]); err != nil {
+				return err
+			}
+
+			return insert(expected[2:])
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertTodos(t, expected, actual)
+	})
+
+	t.Run("import failed", func(t *testing.T) {
+		store := newStore(t)
+		importErr := errors.New("import failed")
+
+		err := store.Import(ctx, func(insert func(todos []model.Todo) error) error {
+			if err := insert([]model.Todo{newTodo(0)}); err != nil {
+				return err
+			}
+
+			return importErr
+		})
+		if !errors.Is(err, importErr) {
+			t.Fatalf("expected import error, got %v", err)
+		}
+
+		actual, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(actual) != 0 {
+			t.Errorf("expected failed import to store no todos, got %d", len(actual))
+		}
+	})
+
+	t.Run("run in tx", func(t *testing.T) {
+		store := newStore(t)
+		todos := createTodos(t, store, 1)
+		expected := []model.Todo{newTodo(1), newTodo(2)}
+
+		err := store.RunInTx(ctx, func(tx todo.Store) error {
+			for _, item := range expected {
+				if err := tx.Create(ctx, item); err != nil {
+					return err
+				}
+			}
+
+			// operations running in their own transaction join the outer one
+			if _, err := tx.Delete(ctx, todos[0].ID); err != nil {
+				return err
+			}
+
+			actual, err := tx.List(ctx)
+			if err != nil {
+				return err
+			}
+
+			assertTodos(t, expected, actual)
+
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertTodos(t, expected, actual)
+	})
+
+	t.Run("run in tx failed", func(t *testing.T) {
+		store := newStore(t)
+		expected := createTodos(t, store, 1)
+		txErr := errors.New("tx failed")
+
+		err := store.RunInTx(ctx, func(tx todo.Store) error {
+			if err := tx.Create(ctx, newTodo(1)); err != nil {
+				return err
+			}
+
+			if _, err := tx.Delete(ctx, expected[0].ID); err != nil {
+				return err
+			}
+
+			return txErr
+		})
+		if !errors.Is(err, txErr) {
+			t.Fatalf("expected tx error, got %v", err)
+		}
+
+		actual, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertTodos(t, expected, actual)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		store := newStore(t)
+		todos := createTodos(t, store, 2)
+
+		if _, err := store.Delete(ctx, todos[0].ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := store.Get(ctx, todos[0].ID); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected deleted todo to be not found, got %v", err)
+		}
+
+		if _, err := store.Get(ctx, todos[1].ID); err != nil {
+			t.Errorf("expected other todo to exist: %v", err)
+		}
+
+		if _, err := store.Delete(ctx, todos[0].ID); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected not found, got %v", err)
+		}
+	})
+
+	t.Run("delete all", func(t *testing.T) {
+		store := newStore(t)
+		createTodos(t, store, 2)
+
+		if _, err := store.DeleteAll(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(actual) != 0 {
+			t.Errorf("expected no todos, got %d", len(actual))
+		}
+	})
+
+	t.Run("attachments", func(t *testing.T) {
+		store := newStore(t)
+		todos := createTodos(t, store, 2)
+		expected := []model.TodoAttachment{newAttachment(todos[0].ID, 0), newAttachment(todos[0].ID, 1)}
+
+		for _, a := range expected {
+			if err := store.CreateAttachment(ctx, a); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		actual, err := store.GetAttachment(ctx, todos[0].ID, expected[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertAttachments(t, expected[:1], []model.TodoAttachment{actual})
+
+		list, err := store.ListAttachments(ctx, todos[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertAttachments(t, expected, list)
+
+		if _, err := store.GetAttachment(ctx, todos[1].ID, expected[0].ID); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected attachment of other todo to be not found, got %v", err)
+		}
+
+		if err := store.DeleteAttachment(ctx, todos[0].ID, expected[0].ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := store.DeleteAttachment(ctx, todos[0].ID, expected[0].ID); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected not found, got %v", err)
+		}
+
+		list, err = store.ListAttachments(ctx, todos[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertAttachments(t, expected[1:], list)
+	})
+
+	t.Run("attachment of missing todo", func(t *testing.T) {
+		store := newStore(t)
+
+		if err := store.CreateAttachment(ctx, newAttachment(uuid.New(), 0)); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected not found, got %v", err)
+		}
+	})
+
+	t.Run("delete with attachments", func(t *testing.T) {
+		store := newStore(t)
+		todos := createTodos(t, store, 2)
+		attachments := []model.TodoAttachment{newAttachment(todos[0].ID, 0), newAttachment(todos[1].ID, 1)}
+
+		for _, a := range attachments {
+			if err := store.CreateAttachment(ctx, a); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		deleted, err := store.Delete(ctx, todos[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertIDs(t, []uuid.UUID{attachments[0].ID}, deleted)
+
+		if _, err := store.GetAttachment(ctx, todos[0].ID, attachments[0].ID); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected attachment of deleted todo to be not found, got %v", err)
+		}
+
+		deleted, err = store.DeleteAll(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertIDs(t, []uuid.UUID{attachments[1].ID}, deleted)
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		store := newStore(t)
+		todos := createTodos(t, store, 2)
+		expected := []model.TodoComment{newComment(todos[0].ID, 0), newComment(todos[0].ID, 1), newComment(todos[0].ID, 2)}
+
+		// comments are listed in order of creation time rather than insertion
+		for _, c := range []model.TodoComment{expected[2], expected[0], expected[1], newComment(todos[1].ID, 3)} {
+			if err := store.CreateComment(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		actual, err := store.GetComment(ctx, todos[0].ID, expected[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertComments(t, expected[:1], []model.TodoComment{actual})
+
+		if _, err := store.GetComment(ctx, todos[1].ID, expected[0].ID); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected comment of other todo to be not found, got %v", err)
+		}
+
+		page, err := store.ListComments(ctx, todos[0].ID, todo.CommentCursor{}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertComments(t, expected[:2], page)
+
+		after := todo.CommentCursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID}
+
+		page, err = store.ListComments(ctx, todos[0].ID, after, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertComments(t, expected[2:], page)
+	})
+
+	t.Run("comment of missing todo", func(t *testing.T) {
+		store := newStore(t)
+
+		if err := store.CreateComment(ctx, newComment(uuid.New(), 0)); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected not found, got %v", err)
+		}
+	})
+
+	t.Run("edit comment", func(t *testing.T) {
+		store := newStore(t)
+		todos := createTodos(t, store, 1)
+		c := newComment(todos[0].ID, 0)
+
+		if err := store.CreateComment(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+
+		updatedAt := c.CreatedAt.Add(time.Minute)
+
+		actual, err := store.UpdateComment(ctx, todos[0].ID, c.ID, "editor", "edited content", updatedAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		edited := c
+		edited.Content = "edited content"
+		edited.Version = 2
+		edited.UpdatedBy = "editor"
+		edited.UpdatedAt = updatedAt
+
+		assertComments(t, []model.TodoComment{edited}, []model.TodoComment{actual})
+
+		revisions, err := store.ListCommentRevisions(ctx, c.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertRevisions(t, []model.TodoCommentRevision{
+			{CommentID: c.ID, Version: 1, Author: c.Author, Content: c.Content, CreatedAt: c.CreatedAt},
+			{CommentID: c.ID, Version: 2, Author: "editor", Content: "edited content", CreatedAt: updatedAt},
+		}, revisions)
+
+		if _, err := store.UpdateComment(ctx, uuid.New(), c.ID, "editor", "edited content", updatedAt); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected comment of other todo to be not found, got %v", err)
+		}
+
+		if err := store.DeleteComment(ctx, todos[0].ID, c.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := store.DeleteComment(ctx, todos[0].ID, c.ID); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected not found, got %v", err)
+		}
+
+		revisions, err = store.ListCommentRevisions(ctx, c.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertRevisions(t, nil, revisions)
+	})
+
+	t.Run("delete with comments", func(t *testing.T) {
+		store := newStore(t)
+		todos := createTodos(t, store, 1)
+		c := newComment(todos[0].ID, 0)
+
+		if err := store.CreateComment(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := store.Delete(ctx, todos[0].ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := store.GetComment(ctx, todos[0].ID, c.ID); !errors.Is(err, todo.ErrNotFound) {
+			t.Errorf("expected comment of deleted todo to be not found, got %v", err)
+		}
+
+		revisions, err := store.ListCommentRevisions(ctx, c.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertRevisions(t, nil, revisions)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		store := newStore(t)
+
+		const n = 20
+
+		var wg sync.WaitGroup
+		errs := make(chan error, n)
+
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				item := newTodo(i)
+				if err := store.Create(ctx, item); err != nil {
+					errs <- err
+					return
+				}
+
+				if _, err := store.Get(ctx, item.ID); err != nil {
+					errs <- err
+					return
+				}
+
+				if _, err := store.List(ctx); err != nil {
+					errs <- err
+				}
+			}(i)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			t.Error(err)
+		}
+
+		actual, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(actual) != n {
+			t.Errorf("expected %d todos, got %d", n, len(actual))
+		}
+	})
+}
+
+func newTodo(i int) model.Todo {
+	return model.Todo{
+		ID:      uuid.New(),
+		Title:   fmt.Sprintf("todo %d", i),
+		Content: fmt.Sprintf("content of todo %d", i),
+	}
+}
+
+func newAttachment(todoID uuid.UUID, i int) model.TodoAttachment {
+	return model.TodoAttachment{
+		ID:          uuid.New(),
+		TodoID:      todoID,
+		Filename:    fmt.Sprintf("attachment-%d.txt", i),
+		ContentType: "text/plain",
+		Size:        int64(i + 1),
+		// stores keep timestamps with microsecond precision, list is ordered by creation time
+		CreatedAt: time.Date(2022, 12, 1, 12, 0, i, 0, time.UTC),
+	}
+}
+
+func newComment(todoID uuid.UUID, i int) model.TodoComment {
+	createdAt := time.Date(2023, 1, 1, 12, 0, i, 0, time.UTC)
+
+	return model.TodoComment{
+		ID:        uuid.New(),
+		TodoID:    todoID,
+		Author:    fmt.Sprintf("author %d", i),
+		Content:   fmt.Sprintf("comment *%d*", i),
+		Version:   1,
+		CreatedAt: createdAt,
+		UpdatedBy: fmt.Sprintf("author %d", i),
+		UpdatedAt: createdAt,
+	}
+}
+
+func createTodos(t *testing.T, store todo.Store, n int) []model.Todo {
+	t.Helper()
+
+	todos := make([]model.Todo, n)
+	for i := range todos {
+		todos[i] = newTodo(i)
+
+		if err := store.Create(context.Background(), todos[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return todos
+}
+
+// assertTodos compares todos regardless of order as stores don't guarantee it.
+func assertTodos(t *testing.T, expected, actual []model.Todo) {
+	t.Helper()
+
+	sortTodos := cmpopts.SortSlices(func(a, b model.Todo) bool {
+		return a.ID.String() < b.ID.String()
+	})
+
+	if diff := cmp.Diff(expected, actual, sortTodos, cmpopts.EquateEmpty()); diff != "" {
+		t.Error("expected todos:", diff)
+	}
+}
+
+// assertAttachments compares attachments in order, cmp compares timestamps by instant as stores may return them in local time.
+func assertAttachments(t *testing.T, expected, actual []model.TodoAttachment) {
+	t.Helper()
+
+	if diff := cmp.Diff(expected, actual, cmpopts.EquateEmpty()); diff != "" {
+		t.Error("expected attachments:", diff)
+	}
+}
+
+func assertIDs(t *testing.T, expected, actual []uuid.UUID) {
+	t.Helper()
+
+	if diff := cmp.Diff(expected, actual, cmpopts.EquateEmpty()); diff != "" {
+		t.Error("expected ids:", diff)
+	}
+}
+
+// assertComments compares comments in order, cmp compares timestamps by instant as stores may return them in local time.
+func assertComments(t *testing.T, expected, actual []model.TodoComment) {
+	t.Helper()
+
+	if diff := cmp.Diff(expected, actual, cmpopts.EquateEmpty()); diff != "" {
+		t.Error("expected comments:", diff)
+	}
+}
+
+// assertRevisions compares revisions in order, cmp compares timestamps by instant as stores may return them in local time.
+func assertRevisions(t *testing.T, expected, actual []model.TodoCommentRevision) {
+	t.Helper()
+
+	if diff := cmp.Diff(expected, actual, cmpopts.EquateEmpty()); diff != "" {
+		t.Error("expected revisions:", diff)
+	}
+}